@@ -0,0 +1,222 @@
+// Package analysis provides a go/analysis analyzer reporting dingo injection mistakes,
+// which are otherwise only detected at runtime when dingo injects into a type.
+//
+// The analyzer reports
+//   - Inject methods with a value receiver of types with inject tags or types used in dingo bindings of the package
+//   - inject tags on unexported fields, pointer to interface fields and struct value fields
+//   - unknown inject tag options, e.g. inject:",optinal"
+//   - inject fields of function types without the Provider suffix
+//
+// It can be run with go vet via cmd/dingovet:
+//
+//	go vet -vettool=$(which dingovet) ./...
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"flamingo.me/dingo/internal/levenshtein"
+)
+
+// Analyzer reports dingo injection mistakes
+var Analyzer = &analysis.Analyzer{
+	Name:     "dingo",
+	Doc:      "report dingo inject tags and Inject methods which fail at runtime",
+	URL:      "https://pkg.go.dev/flamingo.me/dingo/analysis",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// dingoPackage is the import path of dingo, its methods bind the types passed to them
+const dingoPackage = "flamingo.me/dingo"
+
+// injectOptions are the known options of the inject tag
+var injectOptions = []string{"optional"}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.StructType)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	var injectMethods []*ast.FuncDecl
+	bound := make(map[*types.TypeName]bool)
+
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			if node.Recv != nil && len(node.Recv.List) > 0 && node.Name.Name == "Inject" {
+				injectMethods = append(injectMethods, node)
+			}
+		case *ast.StructType:
+			for _, field := range node.Fields.List {
+				checkField(pass, field)
+			}
+		case *ast.CallExpr:
+			collectBoundTypes(pass, node, bound)
+		}
+	})
+
+	for _, decl := range injectMethods {
+		checkInjectMethod(pass, decl, bound)
+	}
+
+	return nil, nil
+}
+
+// collectBoundTypes records the named types passed to methods of dingo, e.g. Bind(new(T)).To(T{})
+func collectBoundTypes(pass *analysis.Pass, call *ast.CallExpr, bound map[*types.TypeName]bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != dingoPackage || fn.Signature().Recv() == nil {
+		return
+	}
+
+	var record func(typ types.Type)
+	record = func(typ types.Type) {
+		switch typ := typ.(type) {
+		case *types.Pointer:
+			record(typ.Elem())
+		case *types.Named:
+			bound[typ.Obj()] = true
+		case *types.Signature:
+			for result := range typ.Results().Variables() {
+				record(result.Type())
+			}
+		}
+	}
+	for _, arg := range call.Args {
+		record(pass.TypesInfo.TypeOf(arg))
+	}
+}
+
+// checkInjectMethod reports Inject methods with a value receiver, see dingo.ErrInvalidInjectReceiver.
+// Inject is a common method name, so only types with inject tags or types used in bindings are reported.
+func checkInjectMethod(pass *analysis.Pass, decl *ast.FuncDecl, bound map[*types.TypeName]bool) {
+	recv := pass.TypesInfo.TypeOf(decl.Recv.List[0].Type)
+	if recv == nil {
+		return
+	}
+	named, ok := recv.(*types.Named) // pointer receivers are valid
+	if !ok {
+		return
+	}
+	if bound[named.Obj()] || hasInjectTags(named) {
+		pass.Reportf(decl.Name.Pos(), "Inject method of %s has a value receiver, dingo requires a pointer receiver", recv)
+	}
+}
+
+// hasInjectTags checks if the type is a struct with inject tags
+func hasInjectTags(named *types.Named) bool {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := range st.NumFields() {
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup("inject"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkField reports inject tags dingo can not handle
+func checkField(pass *analysis.Pass, field *ast.Field) {
+	if field.Tag == nil {
+		return
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	inject, ok := reflect.StructTag(tag).Lookup("inject")
+	if !ok {
+		return
+	}
+
+	typ := pass.TypesInfo.TypeOf(field.Type)
+	if typ == nil {
+		return
+	}
+
+	name := fieldName(field, typ)
+	if !ast.IsExported(name) {
+		pass.Reportf(field.Pos(), "inject tag on unexported field %s, dingo can not set it", name)
+	}
+
+	options := strings.Split(inject, ",")
+	for _, option := range options[1:] {
+		option = strings.TrimSpace(option)
+		if option == "" || slices.Contains(injectOptions, option) {
+			continue
+		}
+		if suggestion := suggest(option, injectOptions); suggestion != "" {
+			pass.Reportf(field.Tag.Pos(), "unknown inject option %q on field %s, did you mean %q?", option, name, suggestion)
+		} else {
+			pass.Reportf(field.Tag.Pos(), "unknown inject option %q on field %s", option, name)
+		}
+	}
+
+	switch underlying := typ.Underlying().(type) {
+	case *types.Pointer:
+		if types.IsInterface(underlying.Elem()) {
+			pass.Reportf(field.Pos(), "field %s is a pointer to interface %s, inject the interface instead", name, underlying.Elem())
+		}
+
+	case *types.Struct:
+		pass.Reportf(field.Pos(), "field %s is a struct value, dingo can only inject into pointers to structs", name)
+
+	case *types.Signature:
+		if underlying.Results().Len() > 0 && !isProvider(typ) {
+			pass.Reportf(field.Pos(), "field %s has function type %s without Provider suffix, dingo only creates providers for named types ending with Provider", name, typ)
+		}
+	}
+}
+
+// fieldName returns the name of the field, embedded fields are named after their type
+func fieldName(field *ast.Field, typ types.Type) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if named, ok := typ.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return typ.String()
+}
+
+// isProvider checks if the type is a named function type ending with Provider
+func isProvider(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && strings.HasSuffix(named.Obj().Name(), "Provider")
+}
+
+// suggest returns the candidate closest to s, if it is close enough to be a typo
+func suggest(s string, candidates []string) string {
+	best, bestDistance := "", len(s)/2+1
+	for _, candidate := range candidates {
+		if d := levenshtein.Distance(s, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,64 @@
+package a
+
+import "flamingo.me/dingo"
+
+type (
+	Iface interface{}
+
+	Concrete struct{}
+
+	ConcreteProvider func() *Concrete
+
+	ConcreteFactory func() *Concrete
+
+	Valid struct {
+		Iface    Iface            `inject:""`
+		Named    Iface            `inject:"named"`
+		Optional Iface            `inject:"named, optional"`
+		Concrete *Concrete        `inject:""`
+		Provider ConcreteProvider `inject:""`
+		Untagged Concrete
+		Other    string `json:"other"`
+	}
+
+	Invalid struct {
+		unexported   Iface           `inject:""`           // want `inject tag on unexported field unexported, dingo can not set it`
+		PointerIface *Iface          `inject:""`           // want `field PointerIface is a pointer to interface a.Iface, inject the interface instead`
+		Value        Concrete        `inject:""`           // want `field Value is a struct value, dingo can only inject into pointers to structs`
+		Misspelled   Iface           `inject:",optinal"`   // want `unknown inject option "optinal" on field Misspelled, did you mean "optional"\?`
+		Unknown      Iface           `inject:"named,lazy"` // want `unknown inject option "lazy" on field Unknown`
+		Factory      ConcreteFactory `inject:""`           // want `field Factory has function type a.ConcreteFactory without Provider suffix`
+		Func         func() Iface    `inject:""`           // want `field Func has function type func\(\) a.Iface without Provider suffix`
+		*Concrete    `inject:""`
+		Iface        `inject:""`
+	}
+
+	ValueReceiver struct {
+		Iface Iface `inject:""`
+	}
+
+	BoundValueReceiver struct{}
+
+	ProvidedValueReceiver struct{}
+
+	UnrelatedValueReceiver struct{}
+
+	PointerReceiver struct {
+		Iface Iface `inject:""`
+	}
+)
+
+func (ValueReceiver) Inject(Iface) {} // want `Inject method of a.ValueReceiver has a value receiver, dingo requires a pointer receiver`
+
+func (BoundValueReceiver) Inject(Iface) {} // want `Inject method of a.BoundValueReceiver has a value receiver, dingo requires a pointer receiver`
+
+func (ProvidedValueReceiver) Inject(Iface) {} // want `Inject method of a.ProvidedValueReceiver has a value receiver, dingo requires a pointer receiver`
+
+func (UnrelatedValueReceiver) Inject() {}
+
+func (*PointerReceiver) Inject(Iface) {}
+
+func configure(injector *dingo.Injector) {
+	injector.Bind(new(Iface)).To(BoundValueReceiver{})
+	injector.Bind(new(Concrete)).ToProvider(func() *ProvidedValueReceiver { return nil })
+}
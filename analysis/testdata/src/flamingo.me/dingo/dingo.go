@@ -0,0 +1,14 @@
+// Package dingo is a stub of the methods the analyzer considers as bindings
+package dingo
+
+type (
+	Injector struct{}
+
+	Binding struct{}
+)
+
+func (*Injector) Bind(what interface{}) *Binding { return new(Binding) }
+
+func (*Binding) To(what interface{}) *Binding { return new(Binding) }
+
+func (*Binding) ToProvider(p interface{}) *Binding { return new(Binding) }
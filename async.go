@@ -0,0 +1,122 @@
+package dingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// asyncSingleton tracks the background construction of an async eager singleton
+type asyncSingleton struct {
+	binding *Binding
+	done    chan struct{}
+	err     error
+}
+
+// startAsyncSingletons starts the construction of all async eager singletons which are not started yet
+func (injector *Injector) startAsyncSingletons() {
+	var bindings []*Binding
+	known := make(map[Dependency]bool)
+
+	for _, typeBindings := range injector.bindings {
+		for _, binding := range typeBindings {
+			key := Dependency{Type: binding.typeof, Annotation: binding.annotatedWith}
+			if !known[key] {
+				// only the first binding for a type and annotation is ever resolved
+				known[key] = true
+				if binding.async {
+					bindings = append(bindings, binding)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(bindings, compareBindings)
+
+	injector.asyncMu.Lock()
+	defer injector.asyncMu.Unlock()
+
+	if injector.async == nil {
+		injector.async = make(map[*Binding]*asyncSingleton)
+	}
+
+	for _, binding := range bindings {
+		if _, ok := injector.async[binding]; ok {
+			continue
+		}
+
+		state := &asyncSingleton{binding: binding, done: make(chan struct{})}
+		injector.async[binding] = state
+		go injector.buildAsyncSingleton(state)
+	}
+}
+
+// buildAsyncSingleton creates the instance in its scope, the interceptors are applied for each consumer as usual
+func (injector *Injector) buildAsyncSingleton(state *asyncSingleton) {
+	defer close(state.done)
+
+	binding := state.binding
+	injector.profile(profileEagerSingleton, binding.typeof, binding.annotatedWith, func() {
+		_, state.err = injector.createScopedInstance(binding.typeof, binding.annotatedWith, binding, false, injector.circularTrace())
+	})
+
+	if state.err != nil {
+		state.err = fmt.Errorf("async eager singleton %s: %w", Dependency{Type: binding.typeof, Annotation: binding.annotatedWith}, state.err)
+	}
+}
+
+// awaitAsync blocks until the async eager singleton of the binding is built.
+// If the construction did not start yet the binding is resolved by the caller as usual.
+func (injector *Injector) awaitAsync(binding *Binding) error {
+	for current := injector; current != nil; current = current.parent {
+		current.asyncMu.Lock()
+		state := current.async[binding]
+		current.asyncMu.Unlock()
+
+		if state != nil {
+			<-state.done
+			return state.err
+		}
+	}
+
+	return nil
+}
+
+// asyncSingletons returns the started async eager singletons ordered by type and annotation
+func (injector *Injector) asyncSingletons() []*asyncSingleton {
+	injector.asyncMu.Lock()
+	defer injector.asyncMu.Unlock()
+
+	states := make([]*asyncSingleton, 0, len(injector.async))
+	for _, state := range injector.async {
+		states = append(states, state)
+	}
+
+	slices.SortFunc(states, func(a, b *asyncSingleton) int {
+		return compareBindings(a.binding, b.binding)
+	})
+
+	return states
+}
+
+// WaitReady blocks until all async eager singletons of the injector and its parents are built,
+// and returns their construction errors. If the context is done first its error is returned.
+func (injector *Injector) WaitReady(ctx context.Context) error {
+	var errs []error
+
+	for current := injector; current != nil; current = current.parent {
+		for _, state := range current.asyncSingletons() {
+			select {
+			case <-state.done:
+				if state.err != nil {
+					errs = append(errs, state.err)
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
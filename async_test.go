@@ -0,0 +1,137 @@
+package dingo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	asyncSlow struct {
+		ready bool
+	}
+
+	asyncConsumer struct {
+		Slow *asyncSlow `inject:""`
+	}
+
+	asyncUnboundIface interface{}
+
+	asyncFailing struct{}
+)
+
+func TestAsyncEagerSingleton(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consumers block until ready", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		releaseOnce := sync.OnceFunc(func() { close(release) })
+		calls := 0
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindScope(NewSingletonScope())
+			injector.Bind(new(asyncSlow)).ToProvider(func() *asyncSlow {
+				<-release
+				calls++
+				return &asyncSlow{ready: true}
+			}).AsAsyncEagerSingleton()
+		}))
+		require.NoError(t, err, "InitModules must not wait for async eager singletons")
+		t.Cleanup(func() {
+			releaseOnce()
+			_ = injector.WaitReady(context.Background())
+		})
+
+		consumed := make(chan *asyncConsumer)
+		go func() {
+			consumer, err := injector.GetInstance(new(asyncConsumer))
+			assert.NoError(t, err)
+			consumed <- consumer.(*asyncConsumer)
+		}()
+
+		select {
+		case <-consumed:
+			t.Fatal("consumer resolved before the async eager singleton was ready")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		releaseOnce()
+		require.NoError(t, injector.WaitReady(context.Background()))
+
+		consumer := <-consumed
+		assert.True(t, consumer.Slow.ready)
+
+		slow, err := injector.GetInstance(new(asyncSlow))
+		require.NoError(t, err)
+		assert.Same(t, consumer.Slow, slow)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("errors are reported", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindScope(NewSingletonScope())
+			injector.Bind(new(asyncFailing)).ToProvider(func(asyncUnboundIface) *asyncFailing {
+				return new(asyncFailing)
+			}).AsAsyncEagerSingleton()
+		}))
+		require.NoError(t, err)
+
+		err = injector.WaitReady(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "async eager singleton dingo.asyncFailing")
+
+		_, err = injector.GetInstance(new(asyncFailing))
+		assert.Error(t, err)
+	})
+
+	t.Run("wait respects the context", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindScope(NewSingletonScope())
+			injector.Bind(new(asyncSlow)).ToProvider(func() *asyncSlow {
+				<-release
+				return new(asyncSlow)
+			}).AsAsyncEagerSingleton()
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			close(release)
+			_ = injector.WaitReady(context.Background())
+		})
+
+		child, err := injector.Child()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, child.WaitReady(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("not started without eager singletons", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithEagerSingletons(false), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.BindScope(NewSingletonScope())
+			injector.Bind(new(asyncSlow)).ToProvider(func() *asyncSlow {
+				return &asyncSlow{ready: true}
+			}).AsAsyncEagerSingleton()
+		})))
+		require.NoError(t, err)
+		require.NoError(t, injector.WaitReady(context.Background()))
+
+		slow, err := injector.GetInstance(new(asyncSlow))
+		require.NoError(t, err)
+		assert.True(t, slow.(*asyncSlow).ready)
+	})
+}
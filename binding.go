@@ -3,6 +3,7 @@ package dingo
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 type (
@@ -15,8 +16,16 @@ type (
 		provider *Provider
 
 		eager         bool
+		async         bool
 		annotatedWith string
 		scope         Scope
+
+		key      string   // key of a multi binding for Before and After
+		priority int      // order of a multi binding
+		before   []string // keys of multi bindings this one comes before
+		after    []string // keys of multi bindings this one comes after
+
+		permitDuplicates bool // kept by the multi binding deduplication
 	}
 
 	// Instance holds quick-references to type and value
@@ -33,8 +42,20 @@ type (
 	}
 )
 
-// To binds a concrete type to a binding
+// To binds a concrete type to a binding, it panics if the type is not assignable, see TryTo
 func (b *Binding) To(what interface{}) *Binding {
+	if err := b.TryTo(what); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryTo binds a concrete type to a binding, or returns an ErrInvalidBinding error
+func (b *Binding) TryTo(what interface{}) error {
+	if what == nil {
+		return fmt.Errorf("%w: can not bind %s to nil", ErrInvalidBinding, b.typeof)
+	}
+
 	to := reflect.TypeOf(what)
 
 	for to.Kind() == reflect.Ptr {
@@ -42,38 +63,69 @@ func (b *Binding) To(what interface{}) *Binding {
 	}
 
 	if !to.AssignableTo(b.typeof) && !reflect.PtrTo(to).AssignableTo(b.typeof) {
-		panic(fmt.Sprintf("%s#%s not assignable to %s#%s", to.PkgPath(), to.Name(), b.typeof.PkgPath(), b.typeof.Name()))
+		return fmt.Errorf("%w: %s#%s not assignable to %s#%s", ErrInvalidBinding, to.PkgPath(), to.Name(), b.typeof.PkgPath(), b.typeof.Name())
 	}
 
 	b.to = to
 
-	return b
+	return nil
 }
 
-// ToInstance binds an instance to a binding
+// ToInstance binds an instance to a binding, it panics if the instance is not assignable, see TryToInstance
 func (b *Binding) ToInstance(instance interface{}) *Binding {
+	if err := b.TryToInstance(instance); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryToInstance binds an instance to a binding, or returns an ErrInvalidBinding error
+func (b *Binding) TryToInstance(instance interface{}) error {
+	if instance == nil {
+		return fmt.Errorf("%w: can not bind %s to a nil instance", ErrInvalidBinding, b.typeof)
+	}
+
+	itype := reflect.TypeOf(instance)
+	if !itype.AssignableTo(b.typeof) && !itype.AssignableTo(reflect.PtrTo(b.typeof)) {
+		return fmt.Errorf("%w: %s#%s not assignable to %s#%s", ErrInvalidBinding, itype.PkgPath(), itype.Name(), b.typeof.PkgPath(), b.typeof.Name())
+	}
+
 	b.instance = &Instance{
-		itype:  reflect.TypeOf(instance),
+		itype:  itype,
 		ivalue: reflect.ValueOf(instance),
 	}
-	if !b.instance.itype.AssignableTo(b.typeof) && !b.instance.itype.AssignableTo(reflect.PtrTo(b.typeof)) {
-		panic(fmt.Sprintf("%s#%s not assignable to %s#%s", b.instance.itype.PkgPath(), b.instance.itype.Name(), b.typeof.PkgPath(), b.typeof.Name()))
+	return nil
+}
+
+// ToProvider binds a provider to an instance. The provider's arguments are automatically injected.
+// It panics if the provider is no function or its result is not assignable, see TryToProvider
+func (b *Binding) ToProvider(p interface{}) *Binding {
+	if err := b.TryToProvider(p); err != nil {
+		panic(err)
 	}
 	return b
 }
 
-// ToProvider binds a provider to an instance. The provider's arguments are automatically injected
-func (b *Binding) ToProvider(p interface{}) *Binding {
+// TryToProvider binds a provider to an instance, or returns an ErrInvalidBinding error
+func (b *Binding) TryToProvider(p interface{}) error {
+	fnc := reflect.ValueOf(p)
+	if fnc.Kind() != reflect.Func || fnc.IsNil() {
+		return fmt.Errorf("%w: provider for %s is %T, not a function", ErrInvalidBinding, b.typeof, p)
+	}
+	if fnc.Type().NumOut() == 0 {
+		return fmt.Errorf("%w: provider %s for %s has no result", ErrInvalidBinding, fnc.Type(), b.typeof)
+	}
+
 	provider := &Provider{
-		fnc:     reflect.ValueOf(p),
+		fnctype: fnc.Type().Out(0),
+		fnc:     fnc,
 		binding: b,
 	}
-	provider.fnctype = provider.fnc.Type().Out(0)
 	if !provider.fnctype.AssignableTo(b.typeof) && !provider.fnctype.AssignableTo(reflect.PtrTo(b.typeof)) {
-		panic(fmt.Sprintf("provider returns %q which is not assignable to %q", provider.fnctype, b.typeof))
+		return fmt.Errorf("%w: provider returns %q which is not assignable to %q", ErrInvalidBinding, provider.fnctype, b.typeof)
 	}
 	b.provider = provider
-	return b
+	return nil
 }
 
 // AnnotatedWith sets the binding's annotation
@@ -92,6 +144,16 @@ func (b *Binding) In(scope Scope) *Binding {
 func (b *Binding) AsEagerSingleton() *Binding {
 	b.In(Singleton)
 	b.eager = true
+	b.async = false
+	return b
+}
+
+// AsAsyncEagerSingleton set's the binding to singleton and requests its construction in the background.
+// Consumers resolving the binding block until the instance is ready, see Injector.WaitReady.
+func (b *Binding) AsAsyncEagerSingleton() *Binding {
+	b.In(Singleton)
+	b.eager = false
+	b.async = true
 	return b
 }
 
@@ -104,13 +166,37 @@ func (p *Provider) Create(injector *Injector) (reflect.Value, error) {
 	in := make([]reflect.Value, p.fnc.Type().NumIn())
 	var err error
 	for i := 0; i < p.fnc.Type().NumIn(); i++ {
-		if in[i], err = injector.getInstance(p.fnc.Type().In(i), "", traceCircular); err != nil {
+		if in[i], err = injector.getInstance(p.fnc.Type().In(i), "", injector.circularTrace()); err != nil {
+			err, _ = withInjectionPoint(err, InjectionPoint{Kind: InjectionPointProviderArgument, Owner: p.binding.typeof, Parameter: i, Type: p.fnc.Type().In(i)})
 			return reflect.Value{}, err
 		}
 		for !in[i].Type().AssignableTo(p.fnc.Type().In(i)) && in[i].Kind() == reflect.Ptr {
 			in[i] = in[i].Elem()
 		}
 	}
-	res := p.fnc.Call(in)[0]
-	return res, injector.requestInjection(res, traceCircular)
+
+	start := time.Now()
+	var res reflect.Value
+	injector.profile(profileProvider, p.binding.typeof, p.binding.annotatedWith, func() {
+		var out []reflect.Value
+		if err = injector.recoverPanic(p.binding.typeof, p.binding.annotatedWith, funcName(p.fnc), func() { out = p.fnc.Call(in) }); err != nil {
+			return
+		}
+		res = out[0]
+		if len(out) == 2 && out[1].Type() == errorType && !out[1].IsNil() {
+			err = &ProviderError{Type: p.binding.typeof, Annotation: p.binding.annotatedWith, Provider: funcName(p.fnc), Err: out[1].Interface().(error)}
+		}
+	})
+	if err == nil {
+		err = injector.requestInjection(res, injector.circularTrace())
+	}
+
+	if len(injector.observers) > 0 {
+		injector.observe(Event{Kind: EventProviderCall, Type: p.binding.typeof, Annotation: p.binding.annotatedWith, Scope: p.binding.scope, BindingKind: BindingKindProvider, Provider: funcName(p.fnc), Duration: time.Since(start), Err: err})
+	}
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return res, nil
 }
@@ -48,3 +48,38 @@ func TestBinding_equal(t *testing.T) {
 	assert.True(t, b.equal(b2))
 	assert.False(t, b.equal(b3))
 }
+
+func TestBinding_TryTo(t *testing.T) {
+	b := &Binding{typeof: reflect.TypeOf(new(string)).Elem()}
+
+	assert.NoError(t, b.TryTo(new(string)))
+	assert.Equal(t, b.to, b.typeof)
+
+	assert.ErrorIs(t, b.TryTo(new(int)), ErrInvalidBinding)
+	assert.ErrorIs(t, b.TryTo(nil), ErrInvalidBinding)
+	assert.Equal(t, b.to, b.typeof, "failed bindings do not change the binding")
+}
+
+func TestBinding_TryToInstance(t *testing.T) {
+	b := &Binding{typeof: reflect.TypeOf(new(string)).Elem()}
+
+	assert.ErrorIs(t, b.TryToInstance(123), ErrInvalidBinding)
+	assert.ErrorIs(t, b.TryToInstance(nil), ErrInvalidBinding)
+	assert.Nil(t, b.instance)
+
+	assert.NoError(t, b.TryToInstance("test"))
+	assert.Equal(t, b.instance.itype, b.typeof)
+}
+
+func TestBinding_TryToProvider(t *testing.T) {
+	b := &Binding{typeof: reflect.TypeOf(new(string)).Elem()}
+
+	assert.ErrorIs(t, b.TryToProvider(func() int { return 123 }), ErrInvalidBinding)
+	assert.ErrorIs(t, b.TryToProvider("test"), ErrInvalidBinding)
+	assert.ErrorIs(t, b.TryToProvider(func() {}), ErrInvalidBinding)
+	assert.ErrorIs(t, b.TryToProvider((func() string)(nil)), ErrInvalidBinding)
+	assert.Nil(t, b.provider)
+
+	assert.NoError(t, b.TryToProvider(func() string { return "test" }))
+	assert.Equal(t, b.provider.fnctype, b.typeof)
+}
@@ -0,0 +1,557 @@
+// Package check verifies dingo bindings statically, without running the application.
+//
+// It loads the packages of a program, finds the Bind, BindMulti, BindSet, BindMap and Override calls and their Try
+// variants in all of its functions (such as Module.Configure methods and ModuleFuncs) via SSA, and cross-references
+// them with every inject tag, Inject method parameter and provider argument of the program.
+//
+// Unbound interfaces, unknown annotations and conflicting duplicate bindings are reported as errors.
+// Bindings which can not be evaluated statically, e.g. with a type or annotation only known at runtime,
+// turn the findings they might affect into reports of severity Unknown instead of errors.
+package check
+
+import (
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Severity of a Diagnostic
+type Severity int
+
+const (
+	// Error is a binding or injection which fails at runtime
+	Error Severity = iota
+	// Unknown is an injection which could not be verified statically
+	Unknown
+)
+
+const dingoPath = "flamingo.me/dingo"
+
+type (
+	// Diagnostic is a single finding
+	Diagnostic struct {
+		Pos      token.Position
+		Severity Severity
+		Message  string
+	}
+
+	// bindingKey identifies a binding by its type and annotation
+	bindingKey struct {
+		typ        string
+		annotation string
+	}
+
+	// binding is a statically evaluated Bind or Override call
+	binding struct {
+		pos      token.Pos
+		target   string // describes To, ToProvider, ToInstance, In and AsEagerSingleton, empty if unknown
+		override bool
+	}
+
+	// injectionPoint is an inject field, Inject method parameter or provider argument
+	injectionPoint struct {
+		pos        token.Pos
+		typ        types.Type
+		annotation string
+		optional   bool
+		what       string
+	}
+
+	checker struct {
+		fset        *token.FileSet
+		bindings    map[bindingKey][]binding
+		annotations map[string][]string // known annotations per bound type
+		mapKeys     map[string][]string
+		dynamic     map[string]bool // types bound with annotations or map keys only known at runtime
+		unknownType bool            // a Bind call with a type only known at runtime
+		points      []injectionPoint
+		diagnostics []Diagnostic
+	}
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "unknown"
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+}
+
+// Check loads the packages matching the patterns and verifies their bindings.
+// The config may be nil, the load mode is always set by Check.
+func Check(config *packages.Config, patterns ...string) ([]Diagnostic, error) {
+	if config == nil {
+		config = new(packages.Config)
+	}
+	config.Mode = packages.LoadAllSyntax | packages.NeedModule
+
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("loading packages: %d error(s)", n)
+	}
+
+	prog, _ := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+
+	c := &checker{
+		fset:        prog.Fset,
+		bindings:    make(map[bindingKey][]binding),
+		annotations: make(map[string][]string),
+		mapKeys:     make(map[string][]string),
+		dynamic:     make(map[string]bool),
+	}
+
+	var user []*ssa.Package
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if isUserPackage(pkg) {
+			if ssaPkg := prog.Package(pkg.Types); ssaPkg != nil {
+				ssaPkg.Build()
+				user = append(user, ssaPkg)
+			}
+		}
+	})
+
+	userFunctions := make(map[*ssa.Package]bool, len(user))
+	for _, pkg := range user {
+		userFunctions[pkg] = true
+		c.collectInjectionPoints(pkg.Pkg)
+	}
+
+	var functions []*ssa.Function
+	for fn := range ssautil.AllFunctions(prog) {
+		if userFunctions[fn.Pkg] && fn.Blocks != nil {
+			functions = append(functions, fn)
+		}
+	}
+	slices.SortFunc(functions, func(a, b *ssa.Function) int {
+		return cmp.Compare(a.Pos(), b.Pos())
+	})
+	for _, fn := range functions {
+		c.collectBindings(fn)
+	}
+
+	c.checkDuplicates()
+	for _, point := range c.points {
+		c.checkInjectionPoint(point)
+	}
+
+	slices.SortFunc(c.diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.Pos.Filename, b.Pos.Filename),
+			cmp.Compare(a.Pos.Line, b.Pos.Line),
+			cmp.Compare(a.Pos.Column, b.Pos.Column),
+			strings.Compare(a.Message, b.Message),
+		)
+	})
+
+	return c.diagnostics, nil
+}
+
+// isUserPackage checks if the package belongs to the program, and is neither part of the standard library nor dingo
+func isUserPackage(pkg *packages.Package) bool {
+	if pkg.Module == nil || pkg.Types == nil {
+		return false
+	}
+	if pkg.PkgPath == dingoPath || strings.HasPrefix(pkg.PkgPath, dingoPath+"/") && !strings.Contains(pkg.PkgPath, "/testdata/") {
+		return false
+	}
+	return true
+}
+
+func (c *checker) report(pos token.Pos, severity Severity, format string, args ...interface{}) {
+	c.diagnostics = append(c.diagnostics, Diagnostic{
+		Pos:      c.fset.Position(pos),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// collectInjectionPoints finds inject tags and Inject methods of the package's named types
+func (c *checker) collectInjectionPoints(pkg *types.Package) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+
+		if st, ok := typeName.Type().Underlying().(*types.Struct); ok {
+			for i := 0; i < st.NumFields(); i++ {
+				inject, ok := reflect.StructTag(st.Tag(i)).Lookup("inject")
+				if !ok {
+					continue
+				}
+
+				point := injectionPoint{
+					pos:        st.Field(i).Pos(),
+					typ:        st.Field(i).Type(),
+					annotation: strings.Split(inject, ",")[0],
+					what:       fmt.Sprintf("field %s.%s", typeName.Name(), st.Field(i).Name()),
+				}
+				for _, option := range strings.Split(inject, ",")[1:] {
+					point.optional = point.optional || strings.TrimSpace(option) == "optional"
+				}
+				c.points = append(c.points, point)
+			}
+		}
+
+		if method := types.NewMethodSet(types.NewPointer(typeName.Type())).Lookup(pkg, "Inject"); method != nil {
+			c.collectArguments(method.Obj().Type().(*types.Signature), token.NoPos, "Inject argument %d of "+typeName.Name())
+		}
+	}
+}
+
+// collectArguments adds the parameters of an Inject method or provider as injection points
+func (c *checker) collectArguments(signature *types.Signature, pos token.Pos, what string) {
+	for i := 0; i < signature.Params().Len(); i++ {
+		point := injectionPoint{
+			pos:  pos,
+			typ:  signature.Params().At(i).Type(),
+			what: fmt.Sprintf(what, i),
+		}
+		if pos == token.NoPos {
+			point.pos = signature.Params().At(i).Pos()
+		}
+		c.points = append(c.points, point)
+	}
+}
+
+// collectBindings evaluates the dingo calls of the function
+func (c *checker) collectBindings(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+
+			// the Try variants return the binding together with an error
+			method := dingoMethod(call, "Injector")
+			switch strings.TrimPrefix(method, "Try") {
+			case "Bind", "Override":
+				c.collectBinding(call, bindingResult(call, method), method == "Override")
+			case "BindMulti", "BindSet":
+				// multibindings always resolve, possibly to an empty slice
+				if _, ok := boundType(call.Call.Args[1]); !ok {
+					c.unknownType = true
+					c.report(call.Pos(), Unknown, "multibinding with a type only known at runtime")
+				}
+			case "BindMap":
+				typ, ok := boundType(call.Call.Args[1])
+				if !ok {
+					c.unknownType = true
+					c.report(call.Pos(), Unknown, "map binding with a type only known at runtime")
+					continue
+				}
+				key := call.Call.Args[2]
+				if value, ok := key.(*ssa.MakeInterface); ok {
+					key = value.X
+				}
+				if !types.Identical(key.Type(), types.Typ[types.String]) && !types.IsInterface(key.Type()) {
+					// only string keys can be injected with a map: annotation
+					continue
+				}
+				if constKey, ok := constString(key); ok {
+					c.mapKeys[typ] = append(c.mapKeys[typ], constKey)
+				} else {
+					c.dynamic[typ] = true
+				}
+			}
+		}
+	}
+}
+
+// bindingResult returns the *dingo.Binding returned by the call, nil if the result of a Try method is not used
+func bindingResult(call *ssa.Call, method string) ssa.Value {
+	if !strings.HasPrefix(method, "Try") {
+		return call
+	}
+	if referrers := call.Referrers(); referrers != nil {
+		for _, referrer := range *referrers {
+			if extract, ok := referrer.(*ssa.Extract); ok && extract.Index == 0 {
+				return extract
+			}
+		}
+	}
+	return nil
+}
+
+// collectBinding evaluates a Bind or Override call and the Binding methods called on its result
+func (c *checker) collectBinding(call *ssa.Call, result ssa.Value, override bool) {
+	typ, ok := boundType(call.Call.Args[1])
+	if !ok {
+		c.unknownType = true
+		c.report(call.Pos(), Unknown, "binding with a type only known at runtime")
+		return
+	}
+
+	var annotation string
+	var annotationKnown = true
+	if override {
+		annotation, annotationKnown = constString(call.Call.Args[2])
+	}
+
+	var targets []string
+	var targetKnown = true
+
+	// follow the chained calls, e.g. injector.Bind(new(T)).AnnotatedWith("a").To(Impl{})
+	var chain []ssa.Value
+	if result != nil {
+		chain = append(chain, result)
+	}
+	for len(chain) > 0 {
+		value := chain[0]
+		chain = chain[1:]
+
+		referrers := value.Referrers()
+		if referrers == nil {
+			continue
+		}
+		for _, referrer := range *referrers {
+			next, ok := referrer.(*ssa.Call)
+			method := dingoMethod(next, "Binding")
+			if !ok || method == "" || next.Call.Args[0] != value {
+				if _, ok := referrer.(*ssa.DebugRef); !ok {
+					// the binding escapes, e.g. it is returned or stored
+					targetKnown = false
+				}
+				continue
+			}
+
+			switch method = strings.TrimPrefix(method, "Try"); method {
+			case "AnnotatedWith":
+				a, ok := constString(next.Call.Args[1])
+				annotationKnown = annotationKnown && ok
+				annotation = a
+			case "To", "ToInstance", "ToProvider", "In":
+				if target, ok := targetType(method, next.Call.Args[1]); ok {
+					targets = append(targets, target)
+				} else {
+					targetKnown = false
+				}
+				if mi, ok := next.Call.Args[1].(*ssa.MakeInterface); ok && method == "ToProvider" {
+					if signature, ok := mi.X.Type().Underlying().(*types.Signature); ok {
+						c.collectArguments(signature, next.Pos(), "argument %d of provider for "+typ)
+					}
+				}
+			case "AsEagerSingleton":
+				targets = append(targets, method)
+			}
+
+			if types.Identical(next.Type(), result.Type()) {
+				chain = append(chain, next)
+			}
+		}
+	}
+
+	if !annotationKnown {
+		c.dynamic[typ] = true
+		c.report(call.Pos(), Unknown, "binding for %s with an annotation only known at runtime", typ)
+		return
+	}
+
+	b := binding{pos: call.Pos(), override: override}
+	if targetKnown {
+		slices.Sort(targets)
+		b.target = strings.Join(targets, " ")
+		if b.target == "" {
+			b.target = "untargeted"
+		}
+	}
+
+	key := bindingKey{typ: typ, annotation: annotation}
+	if len(c.bindings[key]) == 0 {
+		c.annotations[typ] = append(c.annotations[typ], annotation)
+	}
+	c.bindings[key] = append(c.bindings[key], b)
+}
+
+// checkDuplicates reports bindings of the same type and annotation with different targets, unless overridden
+func (c *checker) checkDuplicates() {
+	keys := make([]bindingKey, 0, len(c.bindings))
+	for key := range c.bindings {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b bindingKey) int {
+		return cmp.Or(strings.Compare(a.typ, b.typ), strings.Compare(a.annotation, b.annotation))
+	})
+
+	for _, key := range keys {
+		bindings := c.bindings[key]
+		if slices.ContainsFunc(bindings, func(b binding) bool { return b.override }) {
+			continue
+		}
+
+		first := bindings[0]
+		for _, b := range bindings[1:] {
+			if b.pos == first.pos || first.target == "" || b.target == "" || b.target == first.target {
+				continue
+			}
+			c.report(b.pos, Error, "duplicate binding for %s, already bound at %s", describe(key.typ, key.annotation), c.fset.Position(first.pos))
+		}
+	}
+}
+
+// checkInjectionPoint reports injection points which fail at runtime, like dingo resolves them
+func (c *checker) checkInjectionPoint(point injectionPoint) {
+	if severity, reason, ok := c.resolvable(point.typ, point.annotation, point.optional); !ok {
+		c.report(point.pos, severity, "%s: %s", point.what, reason)
+	}
+}
+
+func (c *checker) resolvable(typ types.Type, annotation string, optional bool) (Severity, string, bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	name := types.TypeString(typ, nil)
+
+	if _, ok := c.bindings[bindingKey{typ: name, annotation: annotation}]; ok {
+		return 0, "", true
+	}
+
+	if key, ok := strings.CutPrefix(annotation, "map:"); ok {
+		if slices.Contains(c.mapKeys[name], key) {
+			return 0, "", true
+		}
+		if c.dynamic[name] || c.unknownType {
+			return Unknown, fmt.Sprintf("map binding %s with key %q not found statically", name, key), false
+		}
+		return Error, fmt.Sprintf("no map binding %s with key %q", name, key), false
+	}
+
+	switch underlying := typ.Underlying().(type) {
+	case *types.Slice:
+		return 0, "", true
+
+	case *types.Map:
+		if basic, ok := underlying.Key().Underlying().(*types.Basic); ok && basic.Kind() == types.String {
+			return 0, "", true
+		}
+
+	case *types.Signature:
+		named, ok := typ.(*types.Named)
+		if ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "iter" {
+			// iter.Seq and iter.Seq2 over multi bindings or map bindings, like slices and maps
+			return 0, "", true
+		}
+		if ok && strings.HasSuffix(named.Obj().Name(), "Provider") && (underlying.Results().Len() == 1 || underlying.Results().Len() == 2) {
+			return c.resolvable(underlying.Results().At(0).Type(), annotation, optional)
+		}
+	}
+
+	if optional {
+		return 0, "", true
+	}
+
+	unknown := c.dynamic[name] || c.unknownType
+
+	if annotation != "" {
+		if unknown {
+			return Unknown, fmt.Sprintf("binding for %s not found statically", describe(name, annotation)), false
+		}
+		if known := c.annotations[name]; len(known) > 0 {
+			return Error, fmt.Sprintf("unknown annotation %q for %s, known annotations: %q", annotation, name, known), false
+		}
+		return Error, fmt.Sprintf("unknown annotation %q, %s is not bound", annotation, name), false
+	}
+
+	switch typ.Underlying().(type) {
+	case *types.Interface:
+		if unknown {
+			return Unknown, fmt.Sprintf("binding for interface %s not found statically", name), false
+		}
+		return Error, fmt.Sprintf("interface %s is not bound", name), false
+
+	case *types.Signature:
+		if unknown {
+			return Unknown, fmt.Sprintf("binding for function %s not found statically", name), false
+		}
+		return Error, fmt.Sprintf("function %s is not bound and not a Provider", name), false
+	}
+
+	return 0, "", true
+}
+
+// dingoMethod returns the name of the called dingo method on the receiver type, or an empty string
+func dingoMethod(call *ssa.Call, receiver string) string {
+	if call == nil {
+		return ""
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Signature.Recv() == nil || callee.Pkg == nil || callee.Pkg.Pkg.Path() != dingoPath {
+		return ""
+	}
+
+	recv := callee.Signature.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); !ok || named.Obj().Name() != receiver {
+		return ""
+	}
+	return callee.Name()
+}
+
+// boundType returns the type passed to Bind, a pointer is dereferenced once like dingo does
+func boundType(value ssa.Value) (string, bool) {
+	mi, ok := value.(*ssa.MakeInterface)
+	if !ok {
+		return "", false
+	}
+
+	typ := mi.X.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	return types.TypeString(typ, nil), true
+}
+
+// targetType describes the argument of To, ToInstance, ToProvider and In
+func targetType(method string, value ssa.Value) (string, bool) {
+	mi, ok := value.(*ssa.MakeInterface)
+	if !ok {
+		return "", false
+	}
+
+	if method == "ToProvider" {
+		switch fn := mi.X.(type) {
+		case *ssa.Function:
+			return method + ":" + fn.String(), true
+		case *ssa.MakeClosure:
+			return method + ":" + fn.Fn.String(), true
+		}
+		return "", false
+	}
+
+	typ, _ := boundType(value)
+	return method + ":" + typ, true
+}
+
+// constString returns the value of a constant string
+func constString(value ssa.Value) (string, bool) {
+	if c, ok := value.(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.String {
+		return constant.StringVal(c.Value), true
+	}
+	return "", false
+}
+
+func describe(typ, annotation string) string {
+	if annotation != "" {
+		return fmt.Sprintf("%s annotated with %q", typ, annotation)
+	}
+	return typ
+}
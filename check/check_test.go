@@ -0,0 +1,39 @@
+package check
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	diagnostics, err := Check(&packages.Config{Dir: filepath.Join("testdata", "app")}, ".")
+	require.NoError(t, err)
+
+	dir, err := filepath.Abs(filepath.Join("testdata", "app"))
+	require.NoError(t, err)
+
+	var reports []string
+	for _, diagnostic := range diagnostics {
+		reports = append(reports, strings.ReplaceAll(diagnostic.String(), dir+string(filepath.Separator), ""))
+	}
+
+	const app = "flamingo.me/dingo/check/testdata/app"
+	assert.Equal(t, []string{
+		"app.go:44:3: error: field Service.Missing: unknown annotation \"missing\" for " + app + ".Greeter, known annotations: [\"\" \"named\"]",
+		"app.go:45:3: error: field Service.Mailer: interface " + app + ".Mailer is not bound",
+		"app.go:51:3: error: field Service.Second: no map binding " + app + ".Plugin with key \"second\"",
+		"app.go:52:3: unknown: field Service.Config: binding for " + app + ".Config annotated with \"config:dsn\" not found statically",
+		"app.go:64:26: error: Inject argument 0 of Service: interface " + app + ".Cache is not bound",
+		"app.go:80:16: unknown: binding for " + app + ".Config with an annotation only known at runtime",
+		"app.go:85:15: error: duplicate binding for " + app + ".Greeter, already bound at " + "app.go:73:15",
+		"app.go:87:43: error: argument 0 of provider for " + app + ".Repository: interface " + app + ".Mailer is not bound",
+		"pointer.go:11:3: error: field Audited.Auditor: interface " + app + ".Auditor is not bound",
+	}, reports)
+}
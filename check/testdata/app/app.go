@@ -0,0 +1,94 @@
+// Package app is checked by the check package tests.
+package app
+
+import (
+	"os"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	Greeter interface {
+		Greet() string
+	}
+
+	Logger interface {
+		Log(string)
+	}
+
+	Mailer interface {
+		Send(string)
+	}
+
+	Cache interface{}
+
+	Config interface{}
+
+	Plugin interface{}
+
+	LoggerProvider func() Logger
+
+	greeter struct{}
+
+	otherGreeter struct{}
+
+	logger struct{}
+
+	Repository struct {
+		Logger Logger `inject:""`
+	}
+
+	Service struct {
+		Greeter      Greeter           `inject:""`
+		Named        Greeter           `inject:"named"`
+		Missing      Greeter           `inject:"missing"`
+		Mailer       Mailer            `inject:""`
+		OptMailer    Mailer            `inject:",optional"`
+		Provider     LoggerProvider    `inject:""`
+		Plugins      []Plugin          `inject:""`
+		PluginMap    map[string]Plugin `inject:""`
+		First        Plugin            `inject:"map:first"`
+		Second       Plugin            `inject:"map:second"`
+		Config       Config            `inject:"config:dsn"`
+		Repository   *Repository       `inject:""`
+		cacheFactory func() Cache
+	}
+
+	Module struct{}
+)
+
+func (*greeter) Greet() string      { return "hello" }
+func (*otherGreeter) Greet() string { return "hi" }
+func (*logger) Log(string)          {}
+
+func (s *Service) Inject(cache Cache) {
+	s.cacheFactory = func() Cache { return cache }
+}
+
+func NewRepository(mailer Mailer) *Repository {
+	return &Repository{}
+}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(Greeter)).To(greeter{})
+	injector.Bind(new(Greeter)).AnnotatedWith("named").To(greeter{})
+	injector.Bind(new(Logger)).To(logger{}).In(dingo.Singleton)
+	injector.BindMulti(new(Plugin)).ToInstance("plugin")
+	injector.BindMap(new(Plugin), "first").ToInstance("first")
+
+	for _, key := range []string{"dsn"} {
+		injector.Bind(new(Config)).AnnotatedWith("config:" + key).ToInstance(os.Getenv(key))
+	}
+}
+
+var OtherModule = dingo.ModuleFunc(func(injector *dingo.Injector) {
+	injector.Bind(new(Greeter)).To(otherGreeter{})
+	injector.Bind(new(Logger)).To(logger{}).In(dingo.Singleton)
+	injector.Bind(new(Repository)).ToProvider(NewRepository)
+})
+
+type pluginKind int
+
+var KindModule = dingo.ModuleFunc(func(injector *dingo.Injector) {
+	injector.BindMap(new(Plugin), pluginKind(2)).ToInstance("second")
+})
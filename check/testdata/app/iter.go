@@ -0,0 +1,9 @@
+package app
+
+import "iter"
+
+type Registry struct {
+	Plugins       iter.Seq[Plugin]              `inject:""`
+	PluginsOrErr  iter.Seq2[Plugin, error]      `inject:""`
+	PluginsByKind iter.Seq2[pluginKind, Plugin] `inject:""`
+}
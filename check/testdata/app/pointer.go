@@ -0,0 +1,18 @@
+package app
+
+import "flamingo.me/dingo"
+
+type (
+	Auditor interface {
+		Audit(string)
+	}
+
+	Audited struct {
+		Auditor Auditor `inject:""`
+	}
+)
+
+var PointerModule = dingo.ModuleFunc(func(injector *dingo.Injector) {
+	// binds *Auditor, dingo dereferences new(*Auditor) only once
+	injector.Bind(new(*Auditor)).ToInstance(new(Auditor))
+})
@@ -0,0 +1,60 @@
+package app
+
+import "flamingo.me/dingo"
+
+type (
+	Notifier interface {
+		Notify(string)
+	}
+
+	Listener interface{}
+
+	Tried struct {
+		Notifier  Notifier   `inject:""`
+		Named     Notifier   `inject:"named"`
+		Provided  Notifier   `inject:"provided"`
+		Instance  Notifier   `inject:"instance"`
+		Keyed     Plugin     `inject:"map:tried"`
+		Listeners []Listener `inject:""`
+	}
+
+	notifier struct{}
+)
+
+func (*notifier) Notify(string) {}
+
+func newNotifier() Notifier { return new(notifier) }
+
+var TryModule = dingo.ModuleFunc(func(injector *dingo.Injector) {
+	if _, err := injector.TryBind(new(Notifier)); err != nil {
+		panic(err)
+	}
+
+	binding, err := injector.TryBind(new(Notifier))
+	if err != nil {
+		panic(err)
+	}
+	if err := binding.AnnotatedWith("named").TryTo(notifier{}); err != nil {
+		panic(err)
+	}
+
+	provided, _ := injector.TryBind(new(Notifier))
+	if err := provided.AnnotatedWith("provided").TryToProvider(newNotifier); err != nil {
+		panic(err)
+	}
+
+	instance, _ := injector.TryBind(new(Notifier))
+	if err := instance.AnnotatedWith("instance").TryToInstance(new(notifier)); err != nil {
+		panic(err)
+	}
+
+	keyed, _ := injector.TryBindMap(new(Plugin), "tried")
+	keyed.ToInstance("tried")
+
+	if _, err := injector.TryBindMulti(new(Listener)); err != nil {
+		panic(err)
+	}
+	injector.BindSet(new(Listener)).ToInstance("set")
+	set, _ := injector.TryBindSet(new(Listener))
+	set.ToInstance("try set")
+})
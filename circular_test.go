@@ -30,13 +30,27 @@ type (
 )
 
 func TestDingoCircula(t *testing.T) {
-	EnableCircularTracing()
-	defer func() {
+	t.Run("global", func(t *testing.T) {
+		EnableCircularTracing()
+		injector, err := NewInjector()
+		assert.NoError(t, err)
+
+		// the injector keeps the setting of its creation
 		traceCircular = nil
-	}()
 
-	injector, err := NewInjector()
-	assert.NoError(t, err)
+		testDingoCircular(t, injector)
+	})
+
+	t.Run("option", func(t *testing.T) {
+		injector, err := NewInjectorWithOptions(WithCircularTracing())
+		assert.NoError(t, err)
+
+		testDingoCircular(t, injector)
+	})
+}
+
+func testDingoCircular(t *testing.T, injector *Injector) {
+	t.Helper()
 
 	assert.Panics(t, func() {
 		i, err := injector.GetInstance(new(circA))
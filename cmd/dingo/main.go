@@ -0,0 +1,77 @@
+// Command dingo provides tooling for applications using dingo.
+//
+// Usage:
+//
+//	dingo check [-tags tag,list] [-unknown=false] [packages]
+//
+// check verifies the bindings of the program statically, see package flamingo.me/dingo/check.
+// It exits with status 1 if errors are found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+
+	"flamingo.me/dingo/check"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "check":
+		os.Exit(runCheck(os.Args[2:]))
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dingo check [-tags tag,list] [-unknown=false] [packages]")
+	os.Exit(2)
+}
+
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	tags := flags.String("tags", "", "comma-separated list of build tags")
+	unknown := flags.Bool("unknown", true, "report injections which can not be verified statically")
+	_ = flags.Parse(args)
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	config := new(packages.Config)
+	if *tags != "" {
+		config.BuildFlags = []string{"-tags=" + *tags}
+	}
+
+	diagnostics, err := check.Check(config, patterns...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dingo check:", err)
+		return 1
+	}
+
+	var errors int
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == check.Unknown && !*unknown {
+			continue
+		}
+		if diagnostic.Severity == check.Error {
+			errors++
+		}
+		fmt.Println(diagnostic)
+	}
+
+	if errors > 0 {
+		fmt.Fprintf(os.Stderr, "dingo check: %d error(s)\n", errors)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+// Command dingogen generates typed constructors for types wired by dingo modules.
+//
+// It writes a driver program which initializes an injector with the given modules and runs dingogen.Generate,
+// runs the driver with `go run` in the current module, and writes the result to the output file:
+//
+//	dingogen -o dingo_gen.go -module example.com/app.Module -root NewServer=*example.com/app.Server
+//
+// Modules are given as import path and type name, they are created with new(T).
+// Roots are given as Name=Type or Name=Type@annotation, where the type can be prefixed with * for pointers.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+type (
+	// qualifiedType is a type given as import path and name, e.g. *example.com/app.Server
+	qualifiedType struct {
+		Pointer bool
+		Path    string
+		Name    string
+	}
+
+	root struct {
+		Name       string
+		Type       qualifiedType
+		Annotation string
+	}
+
+	driver struct {
+		Package     string
+		PackagePath string
+		Imports     map[string]string
+		Modules     []string
+		Roots       []driverRoot
+	}
+
+	driverRoot struct {
+		Name       string
+		Type       string
+		Annotation string
+	}
+
+	listFlag []string
+)
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+var driverTemplate = template.Must(template.New("driver").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/dingogen"
+{{range $path, $alias := .Imports}}	{{$alias}} {{printf "%q" $path}}
+{{end}})
+
+func main() {
+	injector, err := dingo.NewInjectorWithOptions(dingo.WithEagerSingletons(false), dingo.WithModules(
+{{- range .Modules}}
+		new({{.}}),
+{{- end}}
+	))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := dingogen.Generate(injector, dingogen.Config{
+		Package:     {{printf "%q" .Package}},
+		PackagePath: {{printf "%q" .PackagePath}},
+		Roots: []dingogen.Root{
+{{- range .Roots}}
+			{Name: {{printf "%q" .Name}}, Type: reflect.TypeFor[{{.Type}}](), Annotation: {{printf "%q" .Annotation}}},
+{{- end}}
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	_, _ = os.Stdout.Write(src)
+}
+`))
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("dingogen: ")
+
+	var modules, roots listFlag
+	output := flag.String("o", "dingo_gen.go", "output file")
+	pkg := flag.String("package", "", "package name of the output file, defaults to the package in the output directory")
+	flag.Var(&modules, "module", "module type as import/path.Type, can be repeated")
+	flag.Var(&roots, "root", "constructor to generate as Name=[*]import/path.Type[@annotation], can be repeated")
+	flag.Parse()
+
+	if len(roots) == 0 {
+		log.Fatal("at least one -root is required")
+	}
+
+	if err := run(*output, *pkg, modules, roots); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(output, pkg string, moduleSpecs, rootSpecs []string) error {
+	d := driver{Imports: make(map[string]string)}
+
+	dir, err := filepath.Abs(filepath.Dir(output))
+	if err != nil {
+		return err
+	}
+	d.PackagePath, d.Package = goList(dir)
+	if pkg != "" {
+		d.Package = pkg
+	}
+	if d.Package == "" {
+		d.Package = filepath.Base(dir)
+	}
+
+	for _, spec := range moduleSpecs {
+		typ, err := parseType(spec)
+		if err != nil {
+			return fmt.Errorf("-module %s: %w", spec, err)
+		}
+		d.Modules = append(d.Modules, d.typeExpr(typ))
+	}
+
+	for _, spec := range rootSpecs {
+		r, err := parseRoot(spec)
+		if err != nil {
+			return fmt.Errorf("-root %s: %w", spec, err)
+		}
+		d.Roots = append(d.Roots, driverRoot{Name: r.Name, Type: d.typeExpr(r.Type), Annotation: r.Annotation})
+	}
+
+	// the driver must be part of the current module to import its packages
+	tmp, err := os.MkdirTemp(".", "dingogendriver")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var src bytes.Buffer
+	if err := driverTemplate.Execute(&src, d); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "main.go"), src.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.Base(tmp))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running driver: %w", err)
+	}
+
+	return os.WriteFile(output, stdout.Bytes(), 0o644)
+}
+
+// goList returns the import path and name of the package in the directory, if there is one
+func goList(dir string) (string, string) {
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}} {{.Name}}", dir).Output()
+	if err != nil {
+		return "", ""
+	}
+	path, name, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	return path, name
+}
+
+// typeExpr imports the type's package in the driver and returns the type expression
+func (d *driver) typeExpr(typ qualifiedType) string {
+	alias, ok := d.Imports[typ.Path]
+	if !ok {
+		alias = "p" + strconv.Itoa(len(d.Imports))
+		d.Imports[typ.Path] = alias
+	}
+
+	expr := alias + "." + typ.Name
+	if typ.Pointer {
+		expr = "*" + expr
+	}
+	return expr
+}
+
+// parseType parses [*]import/path.Type
+func parseType(spec string) (qualifiedType, error) {
+	var typ qualifiedType
+	typ.Pointer = strings.HasPrefix(spec, "*")
+	spec = strings.TrimPrefix(spec, "*")
+
+	dot := strings.LastIndex(spec, ".")
+	if dot <= strings.LastIndex(spec, "/") || dot == len(spec)-1 {
+		return typ, errors.New("expected import/path.Type")
+	}
+
+	typ.Path, typ.Name = spec[:dot], spec[dot+1:]
+	return typ, nil
+}
+
+// parseRoot parses Name=[*]import/path.Type[@annotation]
+func parseRoot(spec string) (root, error) {
+	var r root
+
+	name, typeSpec, ok := strings.Cut(spec, "=")
+	if !ok || name == "" {
+		return r, errors.New("expected Name=Type")
+	}
+	r.Name = name
+
+	typeSpec, r.Annotation, _ = strings.Cut(typeSpec, "@")
+
+	var err error
+	r.Type, err = parseType(typeSpec)
+	return r, err
+}
@@ -0,0 +1,49 @@
+// Command dingointercept generates proxies for interfaces, which call the intercept.MethodInterceptors bound for
+// the interface on every method call.
+//
+// It generates the proxies for the interfaces declared in the package of the output file:
+//
+//	dingointercept -o intercept_gen.go -type Service,Repository
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"flamingo.me/dingo/intercept/interceptgen"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("dingointercept: ")
+
+	output := flag.String("o", "intercept_gen.go", "output file")
+	typeNames := flag.String("type", "", "comma separated names of the interfaces")
+	tags := flag.String("tags", "", "comma separated build tags")
+	flag.Parse()
+
+	if *typeNames == "" {
+		log.Fatal("-type is required")
+	}
+
+	config := interceptgen.Config{
+		Dir:    filepath.Dir(*output),
+		Types:  strings.Split(*typeNames, ","),
+		Output: filepath.Base(*output),
+	}
+	if *tags != "" {
+		config.Tags = strings.Split(*tags, ",")
+	}
+
+	src, err := interceptgen.Generate(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,15 @@
+// Command dingovet runs the dingo analyzer, it is meant to be used with go vet:
+//
+//	go install flamingo.me/dingo/cmd/dingovet
+//	go vet -vettool=$(which dingovet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"flamingo.me/dingo/analysis"
+)
+
+func main() {
+	unitchecker.Main(analysis.Analyzer)
+}
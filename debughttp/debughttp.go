@@ -0,0 +1,350 @@
+// Package debughttp serves the wiring of a running dingo injector via HTTP, similar to net/http/pprof.
+//
+// The handler renders bindings, modules, scopes, interceptors and instantiated singletons of the injector
+// and all of its parents as HTML, or as JSON if requested with ?format=json or an Accept: application/json header.
+// A type can be explained via <prefix>/explain?type=<type name>&annotation=<annotation>.
+//
+// To register the handler at /debug/dingo/:
+//
+//	debughttp.Register(http.DefaultServeMux, injector)
+package debughttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	// State is the inspected state of an injector and its parents, the injector itself comes first
+	State struct {
+		Injectors []InjectorState `json:"injectors"`
+	}
+
+	// InjectorState describes a single injector
+	InjectorState struct {
+		Level        int           `json:"level"`
+		Modules      []string      `json:"modules"`
+		Scopes       []string      `json:"scopes"`
+		Bindings     []Binding     `json:"bindings"`
+		Interceptors []Interceptor `json:"interceptors"`
+		Singletons   []Singleton   `json:"singletons"`
+	}
+
+	// Binding describes a binding, multibinding or map binding
+	Binding struct {
+		Kind       string `json:"kind"`
+		Type       string `json:"type"`
+		Annotation string `json:"annotation,omitempty"`
+		Key        string `json:"key,omitempty"`
+		Index      int    `json:"index,omitempty"`
+		To         string `json:"to,omitempty"`
+		Provider   string `json:"provider,omitempty"`
+		Instance   string `json:"instance,omitempty"`
+		Scope      string `json:"scope,omitempty"`
+	}
+
+	// Interceptor describes an interceptor bound for an interface
+	Interceptor struct {
+		Type        string `json:"type"`
+		Interceptor string `json:"interceptor"`
+	}
+
+	// Singleton describes an already created singleton instance
+	Singleton struct {
+		Type       string `json:"type"`
+		Annotation string `json:"annotation,omitempty"`
+		Instance   string `json:"instance"`
+		Scope      string `json:"scope"`
+	}
+
+	// Explanation is the result of an explain request
+	Explanation struct {
+		Type        string `json:"type"`
+		Annotation  string `json:"annotation,omitempty"`
+		Explanation string `json:"explanation"`
+	}
+
+	handler struct {
+		injector *dingo.Injector
+	}
+)
+
+const (
+	kindBinding      = "binding"
+	kindMultibinding = "multibinding"
+	kindMapbinding   = "mapbinding"
+)
+
+// Register registers the debug handler for the injector at /debug/dingo/ on the given mux
+func Register(mux *http.ServeMux, injector *dingo.Injector) {
+	mux.Handle("/debug/dingo/", Handler(injector))
+}
+
+// Handler returns a http.Handler serving the state of the injector.
+// Requests to a path ending in /explain explain a single type, all other requests render the overview.
+func Handler(injector *dingo.Injector) http.Handler {
+	return &handler{injector: injector}
+}
+
+// ServeHTTP renders the overview or an explanation
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/explain") {
+		h.explain(w, r)
+		return
+	}
+
+	state := Inspect(h.injector)
+
+	if wantsJSON(r) {
+		writeJSON(w, state)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := overviewTemplate.Execute(w, state); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) explain(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("type")
+	annotation := r.URL.Query().Get("annotation")
+
+	typ := FindType(h.injector, name)
+	if typ == nil {
+		http.Error(w, fmt.Sprintf("type %q is not known to the injector", name), http.StatusNotFound)
+		return
+	}
+
+	explanation := Explanation{
+		Type:        typ.String(),
+		Annotation:  annotation,
+		Explanation: h.injector.Explain(typ, annotation),
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, explanation)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = fmt.Fprint(w, explanation.Explanation)
+}
+
+// Inspect collects the state of the injector and its parents
+func Inspect(injector *dingo.Injector) State {
+	var state State
+
+	for level, current := 0, injector; current != nil; level++ {
+		injectorState := InjectorState{Level: level}
+		var parent *dingo.Injector
+
+		current.Inspect(dingo.Inspector{
+			InspectBinding: func(of reflect.Type, annotation string, to reflect.Type, provider, instance *reflect.Value, in dingo.Scope) {
+				injectorState.Bindings = append(injectorState.Bindings, newBinding(kindBinding, of, annotation, to, provider, instance, in))
+			},
+			InspectMultiBinding: func(of reflect.Type, index int, annotation string, to reflect.Type, provider, instance *reflect.Value, in dingo.Scope) {
+				binding := newBinding(kindMultibinding, of, annotation, to, provider, instance, in)
+				binding.Index = index
+				injectorState.Bindings = append(injectorState.Bindings, binding)
+			},
+			InspectMapBinding: func(of reflect.Type, key string, annotation string, to reflect.Type, provider, instance *reflect.Value, in dingo.Scope) {
+				binding := newBinding(kindMapbinding, of, annotation, to, provider, instance, in)
+				binding.Key = key
+				injectorState.Bindings = append(injectorState.Bindings, binding)
+			},
+			InspectModule: func(module dingo.Module) {
+				injectorState.Modules = append(injectorState.Modules, reflect.TypeOf(module).String())
+			},
+			InspectInterceptor: func(of reflect.Type, interceptor reflect.Type) {
+				injectorState.Interceptors = append(injectorState.Interceptors, Interceptor{Type: of.String(), Interceptor: interceptor.String()})
+			},
+			InspectScope: func(scope dingo.Scope) {
+				injectorState.Scopes = append(injectorState.Scopes, fmt.Sprintf("%T", scope))
+			},
+			InspectSingleton: func(of reflect.Type, annotation string, instance reflect.Value, in dingo.Scope) {
+				injectorState.Singletons = append(injectorState.Singletons, Singleton{
+					Type:       of.String(),
+					Annotation: annotation,
+					Instance:   instance.Type().String(),
+					Scope:      fmt.Sprintf("%T", in),
+				})
+			},
+			InspectParent: func(p *dingo.Injector) {
+				parent = p
+			},
+		})
+
+		injectorState.sort()
+		state.Injectors = append(state.Injectors, injectorState)
+		current = parent
+	}
+
+	return state
+}
+
+// FindType looks up a type by its name among all bound types of the injector and its parents.
+// The name is either the type's string representation such as "*pkg.Type" or its full package path and name.
+func FindType(injector *dingo.Injector, name string) reflect.Type {
+	name = strings.TrimPrefix(name, "*")
+
+	var found reflect.Type
+	match := func(types ...reflect.Type) {
+		for _, t := range types {
+			for t != nil && t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if found == nil && t != nil && (t.String() == name || (t.Name() != "" && t.PkgPath()+"."+t.Name() == name)) {
+				found = t
+			}
+		}
+	}
+
+	for current := injector; current != nil && found == nil; {
+		var parent *dingo.Injector
+
+		current.Inspect(dingo.Inspector{
+			InspectBinding: func(of reflect.Type, _ string, to reflect.Type, _, _ *reflect.Value, _ dingo.Scope) {
+				match(of, to)
+			},
+			InspectMultiBinding: func(of reflect.Type, _ int, _ string, to reflect.Type, _, _ *reflect.Value, _ dingo.Scope) {
+				match(of, to)
+			},
+			InspectMapBinding: func(of reflect.Type, _ string, _ string, to reflect.Type, _, _ *reflect.Value, _ dingo.Scope) {
+				match(of, to)
+			},
+			InspectInterceptor: func(of reflect.Type, interceptor reflect.Type) {
+				match(of, interceptor)
+			},
+			InspectParent: func(p *dingo.Injector) {
+				parent = p
+			},
+		})
+
+		current = parent
+	}
+
+	return found
+}
+
+func newBinding(kind string, of reflect.Type, annotation string, to reflect.Type, provider, instance *reflect.Value, in dingo.Scope) Binding {
+	binding := Binding{
+		Kind:       kind,
+		Type:       of.String(),
+		Annotation: annotation,
+	}
+
+	if to != nil {
+		binding.To = to.String()
+	}
+	if provider != nil {
+		binding.Provider = funcName(*provider)
+	}
+	if instance != nil && instance.IsValid() {
+		binding.Instance = instance.Type().String()
+	}
+	if in != nil {
+		binding.Scope = fmt.Sprintf("%T", in)
+	}
+
+	return binding
+}
+
+func (s *InjectorState) sort() {
+	sort.Strings(s.Scopes)
+	sort.Slice(s.Bindings, func(i, j int) bool {
+		a, b := s.Bindings[i], s.Bindings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Annotation != b.Annotation {
+			return a.Annotation < b.Annotation
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Index < b.Index
+	})
+	sort.SliceStable(s.Interceptors, func(i, j int) bool {
+		return s.Interceptors[i].Type < s.Interceptors[j].Type
+	})
+	sort.Slice(s.Singletons, func(i, j int) bool {
+		if s.Singletons[i].Type != s.Singletons[j].Type {
+			return s.Singletons[i].Type < s.Singletons[j].Type
+		}
+		return s.Singletons[i].Annotation < s.Singletons[j].Annotation
+	})
+}
+
+func funcName(fnc reflect.Value) string {
+	if f := runtime.FuncForPC(fnc.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fnc.Type().String()
+}
+
+func wantsJSON(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var overviewTemplate = template.Must(template.New("overview").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>dingo</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { border: 1px solid #ccc; padding: 2px 6px; text-align: left; font-family: monospace; }
+</style>
+</head>
+<body>
+<form action="explain">
+<input name="type" placeholder="type, e.g. *pkg.Service" size="50">
+<input name="annotation" placeholder="annotation">
+<input type="submit" value="Explain">
+</form>
+{{range .Injectors}}
+<h1>{{if eq .Level 0}}Injector{{else}}Parent injector {{.Level}}{{end}}</h1>
+<h2>Modules</h2>
+<ol>{{range .Modules}}<li>{{.}}</li>{{end}}</ol>
+<h2>Scopes</h2>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>
+<h2>Bindings</h2>
+<table>
+<tr><th>Kind</th><th>Type</th><th>Annotation</th><th>Key</th><th>To</th><th>Provider</th><th>Instance</th><th>Scope</th></tr>
+{{range .Bindings}}<tr><td>{{.Kind}}</td><td><a href="explain?type={{.Type}}&amp;annotation={{.Annotation}}">{{.Type}}</a></td><td>{{.Annotation}}</td><td>{{if eq .Kind "multibinding"}}{{.Index}}{{else}}{{.Key}}{{end}}</td><td>{{.To}}</td><td>{{.Provider}}</td><td>{{.Instance}}</td><td>{{.Scope}}</td></tr>
+{{end}}</table>
+<h2>Interceptors</h2>
+<table>
+<tr><th>Type</th><th>Interceptor</th></tr>
+{{range .Interceptors}}<tr><td>{{.Type}}</td><td>{{.Interceptor}}</td></tr>
+{{end}}</table>
+<h2>Singletons</h2>
+<table>
+<tr><th>Type</th><th>Annotation</th><th>Instance</th><th>Scope</th></tr>
+{{range .Singletons}}<tr><td>{{.Type}}</td><td>{{.Annotation}}</td><td>{{.Instance}}</td><td>{{.Scope}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,152 @@
+package debughttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	TestIface interface {
+		Test() string
+	}
+
+	testImpl struct{}
+
+	testInterceptor struct {
+		TestIface
+	}
+
+	testModule struct{}
+)
+
+func (*testImpl) Test() string { return "test" }
+
+func (*testModule) Configure(injector *dingo.Injector) {
+	injector.Bind(new(TestIface)).To(testImpl{}).AsEagerSingleton()
+	injector.BindMulti(new(TestIface)).To(testImpl{})
+	injector.BindMap(new(TestIface), "key").To(testImpl{})
+	injector.BindInterceptor(new(TestIface), testInterceptor{})
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	injector, err := dingo.NewInjector(new(testModule))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	Register(mux, injector)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func get(t *testing.T, url string, accept string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		resp := get(t, server.URL+"/debug/dingo/", "application/json")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var state State
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+		require.Len(t, state.Injectors, 1)
+
+		injector := state.Injectors[0]
+		assert.Equal(t, []string{"*debughttp.testModule"}, injector.Modules)
+		assert.Contains(t, injector.Scopes, "*dingo.SingletonScope")
+		assert.Contains(t, injector.Bindings, Binding{Kind: kindBinding, Type: "debughttp.TestIface", To: "debughttp.testImpl", Scope: "*dingo.SingletonScope"})
+		assert.Contains(t, injector.Bindings, Binding{Kind: kindMultibinding, Type: "debughttp.TestIface", To: "debughttp.testImpl"})
+		assert.Contains(t, injector.Bindings, Binding{Kind: kindMapbinding, Type: "debughttp.TestIface", Key: "key", To: "debughttp.testImpl"})
+		assert.Equal(t, []Interceptor{{Type: "debughttp.TestIface", Interceptor: "debughttp.testInterceptor"}}, injector.Interceptors)
+		assert.Contains(t, injector.Singletons, Singleton{Type: "debughttp.TestIface", Instance: "*debughttp.testImpl", Scope: "*dingo.SingletonScope"})
+	})
+
+	t.Run("html", func(t *testing.T) {
+		t.Parallel()
+
+		resp := get(t, server.URL+"/debug/dingo/", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+
+		resp := get(t, server.URL+"/debug/dingo/explain?type=flamingo.me/dingo/debughttp.TestIface&format=json", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var explanation Explanation
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&explanation))
+		assert.Equal(t, "debughttp.TestIface", explanation.Type)
+		assert.Contains(t, explanation.Explanation, "to: debughttp.testImpl")
+		assert.Contains(t, explanation.Explanation, "intercepted by debughttp.testInterceptor")
+	})
+
+	t.Run("explain unknown type", func(t *testing.T) {
+		t.Parallel()
+
+		resp := get(t, server.URL+"/debug/dingo/explain?type=unknown", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+type otherSingleton struct{}
+
+func TestInspectSingletons(t *testing.T) {
+	t.Parallel()
+
+	injector, err := dingo.NewInjector(new(testModule))
+	require.NoError(t, err)
+
+	other, err := dingo.NewInjector(dingo.ModuleFunc(func(injector *dingo.Injector) {
+		injector.Bind(new(otherSingleton)).AsEagerSingleton()
+		injector.Bind(new(fmt.Stringer)).ToInstance(new(strings.Builder)).In(dingo.ChildSingleton)
+	}))
+	require.NoError(t, err)
+
+	state := Inspect(injector)
+	require.Len(t, state.Injectors, 1)
+	assert.Equal(t, []Singleton{{Type: "debughttp.TestIface", Instance: "*debughttp.testImpl", Scope: "*dingo.SingletonScope"}}, state.Injectors[0].Singletons)
+
+	child, err := other.Child()
+	require.NoError(t, err)
+	_, err = child.GetInstance(new(fmt.Stringer))
+	require.NoError(t, err)
+
+	state = Inspect(child)
+	require.Len(t, state.Injectors, 2)
+	assert.Equal(t, []Singleton{{Type: "fmt.Stringer", Instance: "*strings.Builder", Scope: "*dingo.ChildSingletonScope"}}, state.Injectors[0].Singletons)
+	assert.Equal(t, []Singleton{{Type: "debughttp.otherSingleton", Instance: "*debughttp.otherSingleton", Scope: "*dingo.SingletonScope"}}, state.Injectors[1].Singletons)
+}
@@ -0,0 +1,149 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// decorator wraps instances of a type with the result of a function
+type decorator struct {
+	fnc reflect.Value
+}
+
+// BindDecorator decorates every resolved instance of a type with the decorator function, e.g.
+//
+//	injector.BindDecorator(new(Service), func(next Service, logger *slog.Logger) Service {
+//		return &loggingService{next: next, logger: logger}
+//	})
+//
+// The first parameter receives the decorated instance, the other parameters are injected like provider arguments.
+// The decorator returns the instance to use instead, optionally with an error.
+// Interfaces are decorated with func(next I) I, concrete types with func(next *T) *T.
+// Decorators are applied in the order of their bindings, on every resolution after the interceptors,
+// and to the elements of multi bindings and map bindings.
+// It panics if the decorator does not match the type, see TryBindDecorator.
+func (injector *Injector) BindDecorator(what interface{}, decorator interface{}) {
+	if err := injector.TryBindDecorator(what, decorator); err != nil {
+		panic(err)
+	}
+}
+
+// TryBindDecorator is BindDecorator, returning an ErrInvalidBinding error instead of panicking
+func (injector *Injector) TryBindDecorator(what interface{}, decoratorFunc interface{}) error {
+	t, err := bindType(what)
+	if err != nil {
+		return err
+	}
+
+	decorated := decoratedType(t)
+	fnc := reflect.ValueOf(decoratorFunc)
+	if fnc.Kind() != reflect.Func || fnc.IsNil() {
+		return fmt.Errorf("%w: decorator for %s is %T, not a function", ErrInvalidBinding, t, decoratorFunc)
+	}
+
+	ftype := fnc.Type()
+	if ftype.NumIn() == 0 || ftype.In(0) != decorated || ftype.IsVariadic() {
+		return fmt.Errorf("%w: decorator %s for %s must take %s as first parameter", ErrInvalidBinding, ftype, t, decorated)
+	}
+	if ftype.NumOut() == 0 || ftype.NumOut() > 2 || ftype.Out(0) != decorated || (ftype.NumOut() == 2 && ftype.Out(1) != errorType) {
+		return fmt.Errorf("%w: decorator %s for %s must return %s or (%s, error)", ErrInvalidBinding, ftype, t, decorated, decorated)
+	}
+
+	if injector.decorators == nil {
+		injector.decorators = make(map[reflect.Type][]*decorator)
+	}
+	injector.decorators[t] = append(injector.decorators[t], &decorator{fnc: fnc})
+	return nil
+}
+
+// decoratedType is the type decorators of t take and return, the interface itself or a pointer to a concrete type
+func decoratedType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return t
+	}
+	return reflect.PointerTo(t)
+}
+
+// decorate applies the decorators of the injector and its parents to the instance
+func (injector *Injector) decorate(final reflect.Value, t reflect.Type, circularTrace []circularTraceEntry) (reflect.Value, error) {
+	// nothing to decorate, e.g. for optional injections of unbound interfaces
+	if isNil(final) {
+		return final, nil
+	}
+
+	for current := injector; current != nil; current = current.parent {
+		for _, decorator := range current.decorators[t] {
+			var err error
+			if final, err = injector.applyDecorator(decorator, final, t, circularTrace); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	return final, nil
+}
+
+// applyDecorator calls the decorator with the instance and its injected arguments
+func (injector *Injector) applyDecorator(d *decorator, final reflect.Value, t reflect.Type, circularTrace []circularTraceEntry) (reflect.Value, error) {
+	ftype := d.fnc.Type()
+
+	in := make([]reflect.Value, ftype.NumIn())
+	in[0] = asDecorated(final, ftype.In(0))
+	if !in[0].IsValid() {
+		return reflect.Value{}, fmt.Errorf("can not decorate %s with %s: instance of %s", t, ftype, final.Type())
+	}
+
+	var err error
+	for i := 1; i < ftype.NumIn(); i++ {
+		if in[i], err = injector.getInstance(ftype.In(i), "", circularTrace); err != nil {
+			err, _ = withInjectionPoint(err, InjectionPoint{Kind: InjectionPointDecoratorArgument, Owner: t, Parameter: i, Type: ftype.In(i)})
+			return reflect.Value{}, err
+		}
+		for !in[i].Type().AssignableTo(ftype.In(i)) && in[i].Kind() == reflect.Ptr {
+			in[i] = in[i].Elem()
+		}
+	}
+
+	var out []reflect.Value
+	if err := injector.recoverPanic(t, "", funcName(d.fnc), func() { out = d.fnc.Call(in) }); err != nil {
+		return reflect.Value{}, err
+	}
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, &ProviderError{Type: t, Provider: funcName(d.fnc), Err: out[1].Interface().(error)}
+	}
+
+	if out[0].Kind() == reflect.Interface && !out[0].IsNil() {
+		return out[0].Elem(), nil
+	}
+	return out[0], nil
+}
+
+// asDecorated converts a resolved instance to the decorator's parameter type, an invalid value if it is not possible
+func asDecorated(final reflect.Value, decorated reflect.Type) reflect.Value {
+	switch {
+	case final.Type().AssignableTo(decorated):
+		v := reflect.New(decorated).Elem()
+		v.Set(final)
+		return v
+
+	case final.Kind() == reflect.Ptr && final.Elem().Type().AssignableTo(decorated):
+		return asDecorated(final.Elem(), decorated)
+
+	case decorated.Kind() == reflect.Ptr && final.Type().AssignableTo(decorated.Elem()):
+		v := reflect.New(decorated.Elem())
+		v.Elem().Set(final)
+		return v
+	}
+
+	return reflect.Value{}
+}
+
+// isNil checks if the value is invalid, a nil pointer or interface, or a pointer to a nil interface
+func isNil(v reflect.Value) bool {
+	switch {
+	case !v.IsValid():
+		return true
+	case v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr:
+		return v.IsNil() || (v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Interface && v.Elem().IsNil())
+	}
+	return false
+}
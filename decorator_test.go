@@ -0,0 +1,149 @@
+package dingo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	decoratorGreeter interface {
+		Greet() string
+	}
+
+	decoratorHello struct{}
+
+	decoratorPrefix struct {
+		next   decoratorGreeter
+		prefix string
+	}
+
+	decoratorService struct {
+		Name string
+	}
+
+	decoratorGreeterProvider func() decoratorGreeter
+
+	decoratorConsumer struct {
+		Greeter  decoratorGreeter            `inject:""`
+		Optional decoratorGreeter            `inject:"missing,optional"`
+		Multi    []decoratorGreeter          `inject:""`
+		Map      map[string]decoratorGreeter `inject:""`
+	}
+)
+
+var errDecorator = errors.New("decorator failed")
+
+func (*decoratorHello) Greet() string { return "hello" }
+
+func (p *decoratorPrefix) Greet() string { return p.prefix + p.next.Greet() }
+
+func TestBindDecorator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("interfaces, multi bindings and map bindings", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(string)).ToInstance("> ")
+			injector.Bind(new(decoratorGreeter)).To(decoratorHello{})
+			injector.BindMulti(new(decoratorGreeter)).To(decoratorHello{})
+			injector.BindMap(new(decoratorGreeter), "key").ToInstance(new(decoratorHello))
+			injector.BindDecorator(new(decoratorGreeter), func(next decoratorGreeter, prefix string) decoratorGreeter {
+				return &decoratorPrefix{next: next, prefix: prefix}
+			})
+			injector.BindDecorator(new(decoratorGreeter), func(next decoratorGreeter) decoratorGreeter {
+				return &decoratorPrefix{next: next, prefix: "!"}
+			})
+		}))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(decoratorConsumer))
+		require.NoError(t, err)
+		consumer := i.(*decoratorConsumer)
+
+		assert.Equal(t, "!> hello", consumer.Greeter.Greet())
+		assert.Nil(t, consumer.Optional)
+		require.Len(t, consumer.Multi, 1)
+		assert.Equal(t, "!> hello", consumer.Multi[0].Greet())
+		assert.Equal(t, "!> hello", consumer.Map["key"].Greet())
+
+		var providers struct {
+			Multi []decoratorGreeterProvider          `inject:""`
+			Map   map[string]decoratorGreeterProvider `inject:""`
+		}
+		require.NoError(t, injector.RequestInjection(&providers))
+		require.Len(t, providers.Multi, 1)
+		assert.Equal(t, "!> hello", providers.Multi[0]().Greet())
+		assert.Equal(t, "!> hello", providers.Map["key"]().Greet())
+	})
+
+	t.Run("concrete types and child injectors", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(decoratorService)).ToInstance(&decoratorService{Name: "service"})
+			injector.BindDecorator(new(decoratorService), func(next *decoratorService) *decoratorService {
+				return &decoratorService{Name: "parent(" + next.Name + ")"}
+			})
+		}))
+		require.NoError(t, err)
+
+		child, err := injector.Child()
+		require.NoError(t, err)
+		child.BindDecorator(new(decoratorService), func(next *decoratorService) (*decoratorService, error) {
+			return &decoratorService{Name: "child(" + next.Name + ")"}, nil
+		})
+
+		i, err := child.GetInstance(new(decoratorService))
+		require.NoError(t, err)
+		assert.Equal(t, "parent(child(service))", i.(*decoratorService).Name)
+
+		i, err = injector.GetInstance(new(decoratorService))
+		require.NoError(t, err)
+		assert.Equal(t, "parent(service)", i.(*decoratorService).Name)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindDecorator(new(decoratorService), func(*decoratorService) (*decoratorService, error) {
+				return nil, errDecorator
+			})
+			injector.BindDecorator(new(decoratorGreeter), func(next decoratorGreeter, _ errorsIface) decoratorGreeter {
+				return next
+			})
+			injector.Bind(new(decoratorGreeter)).To(decoratorHello{})
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(decoratorService))
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.ErrorIs(t, err, errDecorator)
+
+		_, err = injector.GetInstance(new(decoratorGreeter))
+		var unbound *UnboundError
+		require.ErrorAs(t, err, &unbound)
+		assert.Equal(t, []InjectionPoint{{Kind: InjectionPointDecoratorArgument, Owner: reflect.TypeOf(new(decoratorGreeter)).Elem(), Parameter: 1, Type: reflect.TypeOf(new(errorsIface)).Elem()}}, unbound.Path)
+		assert.Contains(t, err.Error(), "argument 1 of decorator for dingo.decoratorGreeter: dingo.errorsIface")
+	})
+
+	t.Run("invalid decorators", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, injector.TryBindDecorator(new(decoratorGreeter), "decorator"), ErrInvalidBinding)
+		assert.ErrorIs(t, injector.TryBindDecorator(new(decoratorGreeter), func() decoratorGreeter { return nil }), ErrInvalidBinding)
+		assert.ErrorIs(t, injector.TryBindDecorator(new(decoratorService), func(decoratorService) decoratorService { return decoratorService{} }), ErrInvalidBinding)
+		assert.ErrorIs(t, injector.TryBindDecorator(new(decoratorGreeter), func(decoratorGreeter) {}), ErrInvalidBinding)
+		assert.ErrorIs(t, injector.TryBindDecorator(new(decoratorGreeter), func(decoratorGreeter) (decoratorGreeter, string) { return nil, "" }), ErrInvalidBinding)
+		assert.NoError(t, injector.TryBindDecorator(new(decoratorGreeter), func(next decoratorGreeter) decoratorGreeter { return next }))
+	})
+}
@@ -0,0 +1,74 @@
+package decorators
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"flamingo.me/dingo/intercept"
+)
+
+// CircuitBreaker fails fast with ErrCircuitOpen after consecutive failures of methods returning an error.
+// After the cooldown one call is let through, the circuit closes again if it succeeds.
+type CircuitBreaker struct {
+	failures int
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	consecutive int
+	openUntil   time.Time
+	probing     bool
+}
+
+// NewCircuitBreaker creates a CircuitBreaker opening after the number of consecutive failures for the cooldown
+func NewCircuitBreaker(failures int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{failures: max(1, failures), cooldown: cooldown, now: time.Now}
+}
+
+// Invoke calls the method if the circuit is closed
+func (b *CircuitBreaker) Invoke(ctx intercept.Invocation) []reflect.Value {
+	if !returnsError(ctx) {
+		return ctx.Proceed()
+	}
+
+	if !b.allow() {
+		return failed(ctx, ErrCircuitOpen)
+	}
+
+	results := ctx.Proceed()
+	b.record(errorOf(results) == nil)
+	return results
+}
+
+// allow checks if a call may pass, while open only a single probing call passes after the cooldown
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.consecutive < b.failures {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record counts the result of a call
+func (b *CircuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.consecutive = 0
+		return
+	}
+
+	b.consecutive++
+	if b.consecutive >= b.failures {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,209 @@
+// Package decorators provides ready-made intercept.MethodInterceptors for cross-cutting concerns:
+// latency histograms published via expvar, slog call logging, retries with backoff, a circuit breaker,
+// and singleflight with optional memoization for idempotent methods.
+//
+// The Module binds the generated proxy of an interface (see cmd/dingointercept) together with the configured
+// interceptors:
+//
+//	injector, err := dingo.NewInjector(
+//		new(app.Module),
+//		decorators.Decorate(new(app.Repository), app.RepositoryProxy{},
+//			decorators.WithLogging(slog.Default(), slog.LevelDebug),
+//			decorators.WithTiming("app.repository"),
+//			decorators.WithRetry(3, 100*time.Millisecond),
+//		),
+//	)
+package decorators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/intercept"
+)
+
+type (
+	// Module binds the proxy of an interface with the configured decorators, see Decorate
+	Module struct {
+		iface        interface{}
+		proxy        interface{}
+		annotation   string
+		interceptors []intercept.MethodInterceptor
+	}
+
+	// Option configures the Module
+	Option func(m *Module)
+
+	// methodFilter restricts a MethodInterceptor to some methods
+	methodFilter struct {
+		methods     []string
+		interceptor intercept.MethodInterceptor
+	}
+)
+
+var (
+	errorType   = reflect.TypeOf(new(error)).Elem()
+	contextType = reflect.TypeOf(new(context.Context)).Elem()
+
+	// ErrCircuitOpen is returned by methods while the circuit breaker is open
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+)
+
+// Decorate creates a module binding the proxy generated for the interface with the configured decorators.
+// The decorators are called in the order of the options, the first one wraps all others.
+// They apply to the bindings of the interface without annotation, including the elements of multi bindings and map
+// bindings, or to those with the annotation given by AnnotatedWith. Modules are identified by interface and annotation,
+// so several interfaces, and several annotations of an interface, can be decorated in one injector.
+func Decorate(iface, proxy interface{}, options ...Option) dingo.Module {
+	m := &Module{iface: iface, proxy: proxy}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Configure binds the proxy and the method interceptors
+func (m *Module) Configure(injector *dingo.Injector) {
+	injector.BindInterceptor(m.iface, m.proxy).AnnotatedWith(m.annotation)
+
+	for _, interceptor := range m.interceptors {
+		intercept.BindAnnotatedMethodInterceptor(injector, m.iface, m.annotation).ToInstance(interceptor)
+	}
+}
+
+// ModuleIdentity identifies the module by the decorated interface and annotation
+func (m *Module) ModuleIdentity() string {
+	return intercept.AnnotationFor(m.iface, m.annotation)
+}
+
+// AnnotatedWith restricts the decorators to the bindings of the interface with the annotation
+func AnnotatedWith(annotation string) Option {
+	return func(m *Module) {
+		m.annotation = annotation
+	}
+}
+
+// WithInterceptor adds a MethodInterceptor, optionally restricted to some methods
+func WithInterceptor(interceptor intercept.MethodInterceptor, methods ...string) Option {
+	return func(m *Module) {
+		m.interceptors = append(m.interceptors, OnlyMethods(interceptor, methods...))
+	}
+}
+
+// WithTiming records the latency of all calls in expvar histograms, see NewTiming
+func WithTiming(name string, methods ...string) Option {
+	return WithInterceptor(NewTiming(name), methods...)
+}
+
+// WithLogging logs all calls, see NewLogging
+func WithLogging(logger *slog.Logger, level slog.Level, methods ...string) Option {
+	return WithInterceptor(NewLogging(logger, level), methods...)
+}
+
+// WithRetry retries failed calls, see NewRetry
+func WithRetry(attempts int, backoff time.Duration, methods ...string) Option {
+	return WithInterceptor(NewRetry(attempts, backoff), methods...)
+}
+
+// WithCircuitBreaker stops calling after consecutive failures, see NewCircuitBreaker
+func WithCircuitBreaker(failures int, cooldown time.Duration, methods ...string) Option {
+	return WithInterceptor(NewCircuitBreaker(failures, cooldown), methods...)
+}
+
+// WithSingleflight shares the results of concurrent identical calls, see NewSingleflight
+func WithSingleflight(ttl time.Duration, methods ...string) Option {
+	return WithInterceptor(NewSingleflight(ttl), methods...)
+}
+
+// OnlyMethods restricts the interceptor to the methods, all methods are intercepted if none are given
+func OnlyMethods(interceptor intercept.MethodInterceptor, methods ...string) intercept.MethodInterceptor {
+	if len(methods) == 0 {
+		return interceptor
+	}
+	return &methodFilter{methods: methods, interceptor: interceptor}
+}
+
+// Invoke calls the interceptor for the configured methods
+func (f *methodFilter) Invoke(ctx intercept.Invocation) []reflect.Value {
+	if slices.Contains(f.methods, ctx.Method) {
+		return f.interceptor.Invoke(ctx)
+	}
+	return ctx.Proceed()
+}
+
+// errorOf returns the error of the last result, if the method returns an error
+func errorOf(results []reflect.Value) error {
+	if len(results) == 0 {
+		return nil
+	}
+	last := results[len(results)-1]
+	if last.Type() != errorType || last.IsNil() {
+		return nil
+	}
+	return last.Interface().(error)
+}
+
+// returnsError checks if the last result of the method is an error
+func returnsError(ctx intercept.Invocation) bool {
+	method, ok := ctx.Interface.MethodByName(ctx.Method)
+	return ok && method.Type.NumOut() > 0 && method.Type.Out(method.Type.NumOut()-1) == errorType
+}
+
+// failed returns zero results with the error as last result
+func failed(ctx intercept.Invocation, err error) []reflect.Value {
+	method, _ := ctx.Interface.MethodByName(ctx.Method)
+	results := make([]reflect.Value, method.Type.NumOut())
+	for i := range results {
+		results[i] = reflect.Zero(method.Type.Out(i))
+	}
+	results[len(results)-1] = reflect.ValueOf(&err).Elem()
+	return results
+}
+
+// callKey identifies a call by method and arguments, a leading context.Context is ignored.
+// Only arguments of value types are supported: booleans, numbers and strings, and arrays, slices, maps and structs
+// of them. ok is false for other arguments, like pointers or interfaces, which do not identify a call by value.
+func callKey(ctx intercept.Invocation) (key string, ok bool) {
+	var sb strings.Builder
+	sb.WriteString(ctx.Method)
+	for i, arg := range ctx.Arguments {
+		if i == 0 && arg.Type().Implements(contextType) {
+			continue
+		}
+		if !valueType(arg.Type()) {
+			return "", false
+		}
+		_, _ = fmt.Fprintf(&sb, "|%#v", arg.Interface())
+	}
+	return sb.String(), true
+}
+
+// valueType checks if the formatted values of the type are equal for equal values
+func valueType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	case reflect.Array, reflect.Slice:
+		return valueType(t.Elem())
+	case reflect.Map:
+		return valueType(t.Key()) && valueType(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if !valueType(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
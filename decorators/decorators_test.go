@@ -0,0 +1,309 @@
+package decorators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/decorators/internal/service"
+	"flamingo.me/dingo/intercept"
+)
+
+// fakeClock is a manually advanced time source
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func decorated(t *testing.T, flaky *service.Flaky, options ...Option) service.Service {
+	t.Helper()
+
+	injector, err := dingo.NewInjector(
+		dingo.ModuleFunc(func(injector *dingo.Injector) {
+			injector.Bind(new(service.Service)).ToInstance(flaky)
+		}),
+		Decorate(new(service.Service), service.ServiceProxy{}, options...),
+	)
+	require.NoError(t, err)
+
+	i, err := injector.GetInstance(new(service.Service))
+	require.NoError(t, err)
+	return i.(service.Service)
+}
+
+func TestDecorate(t *testing.T) {
+	t.Parallel()
+
+	// expvar names are global, the test may run more than once
+	timingName := fmt.Sprintf("decorators_test_%d", time.Now().UnixNano())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	flaky := &service.Flaky{Failures: 2}
+	s := decorated(t, flaky,
+		WithLogging(logger, slog.LevelInfo),
+		WithTiming(timingName),
+		WithRetry(3, time.Millisecond, "Fetch"),
+	)
+
+	result, err := s.Fetch(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "fetched 1", result)
+	assert.Equal(t, int32(3), flaky.Calls.Load())
+	assert.Equal(t, "flaky", s.Name())
+
+	assert.Contains(t, buf.String(), "msg=call interface=service.Service method=Fetch")
+	assert.Contains(t, buf.String(), "msg=call interface=service.Service method=Name")
+
+	var histogram struct {
+		Count   int64            `json:"count"`
+		Buckets map[string]int64 `json:"buckets"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get(timingName).(*expvar.Map).Get("service.Service.Fetch").String()), &histogram))
+	assert.Equal(t, int64(1), histogram.Count, "timing measures the retried call once")
+}
+
+func TestDecorateSeveralInterfaces(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	recorder := func(name string) intercept.MethodInterceptor {
+		return intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			calls = append(calls, name+" "+ctx.Method)
+			return ctx.Proceed()
+		})
+	}
+
+	flaky := new(service.Flaky)
+	injector, err := dingo.NewInjector(
+		dingo.ModuleFunc(func(injector *dingo.Injector) {
+			injector.Bind(new(service.Service)).ToInstance(flaky)
+			injector.Bind(new(service.Counter)).ToInstance(flaky)
+		}),
+		Decorate(new(service.Service), service.ServiceProxy{}, WithInterceptor(recorder("service"))),
+		Decorate(new(service.Counter), service.CounterProxy{}, WithInterceptor(recorder("counter"))),
+	)
+	require.NoError(t, err)
+
+	s, err := injector.GetInstance(new(service.Service))
+	require.NoError(t, err)
+	c, err := injector.GetInstance(new(service.Counter))
+	require.NoError(t, err)
+
+	assert.Equal(t, "flaky", s.(service.Service).Name())
+	assert.Equal(t, 0, c.(service.Counter).Count())
+	assert.Equal(t, []string{"service Name", "counter Count"}, calls)
+}
+
+func TestDecorateAnnotated(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	recorder := func(name string) intercept.MethodInterceptor {
+		return intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			calls = append(calls, name+" "+ctx.Method)
+			return ctx.Proceed()
+		})
+	}
+
+	flaky := new(service.Flaky)
+	injector, err := dingo.NewInjector(
+		dingo.ModuleFunc(func(injector *dingo.Injector) {
+			injector.Bind(new(service.Service)).ToInstance(flaky)
+			injector.Bind(new(service.Service)).AnnotatedWith("audited").ToInstance(flaky)
+			injector.Bind(new(service.Service)).AnnotatedWith("plain").ToInstance(flaky)
+		}),
+		Decorate(new(service.Service), service.ServiceProxy{}, WithInterceptor(recorder("default"))),
+		Decorate(new(service.Service), service.ServiceProxy{}, AnnotatedWith("audited"), WithInterceptor(recorder("audited"))),
+	)
+	require.NoError(t, err)
+
+	for _, annotation := range []string{"", "audited", "plain"} {
+		s, err := injector.GetAnnotatedInstance(new(service.Service), annotation)
+		require.NoError(t, err)
+		assert.Equal(t, "flaky", s.(service.Service).Name())
+	}
+	assert.Equal(t, []string{"default Name", "audited Name"}, calls)
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	flaky := &service.Flaky{Failures: 5}
+	s := decorated(t, flaky, WithRetry(3, time.Millisecond))
+
+	_, err := s.Fetch(context.Background(), "1")
+	assert.ErrorIs(t, err, service.ErrFlaky)
+	assert.Equal(t, int32(3), flaky.Calls.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.Fetch(ctx, "1")
+	assert.ErrorIs(t, err, service.ErrFlaky)
+	assert.Equal(t, int32(4), flaky.Calls.Load(), "a done context stops retrying")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	flaky := &service.Flaky{Failures: 3}
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = clock.Now
+	s := decorated(t, flaky, WithInterceptor(breaker))
+
+	for range 2 {
+		_, err := s.Fetch(context.Background(), "1")
+		assert.ErrorIs(t, err, service.ErrFlaky)
+	}
+
+	_, err := s.Fetch(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), flaky.Calls.Load())
+	assert.Equal(t, "flaky", s.Name(), "methods without error are not affected")
+
+	clock.Advance(59 * time.Second)
+	_, err = s.Fetch(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrCircuitOpen, "the circuit stays open during the cooldown")
+
+	clock.Advance(time.Second)
+	_, err = s.Fetch(context.Background(), "1")
+	assert.ErrorIs(t, err, service.ErrFlaky, "the probing call fails and opens the circuit again")
+	_, err = s.Fetch(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	clock.Advance(time.Minute)
+	result, err := s.Fetch(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "fetched 1", result)
+	_, err = s.Fetch(context.Background(), "1")
+	assert.NoError(t, err)
+}
+
+func TestSingleflight(t *testing.T) {
+	t.Parallel()
+
+	t.Run("memoized", func(t *testing.T) {
+		t.Parallel()
+
+		flaky := &service.Flaky{Failures: 1}
+		s := decorated(t, flaky, WithSingleflight(time.Hour))
+
+		_, err := s.Fetch(context.Background(), "1")
+		assert.ErrorIs(t, err, service.ErrFlaky, "errors are not memoized")
+
+		for range 3 {
+			result, err := s.Fetch(context.Background(), "1")
+			require.NoError(t, err)
+			assert.Equal(t, "fetched 1", result)
+		}
+		_, err = s.Fetch(context.Background(), "2")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), flaky.Calls.Load())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		flaky := &service.Flaky{}
+		s := NewSingleflight(0)
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		interceptors := []intercept.MethodInterceptor{s, intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			close(started)
+			<-release
+			return ctx.Proceed()
+		})}
+
+		var wg sync.WaitGroup
+		results := make([]string, 3)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i > 0 {
+					<-started
+				}
+				r := intercept.Call(new(service.Service), flaky, nil, "Fetch", interceptors, reflect.ValueOf(context.Background()), reflect.ValueOf("1"))
+				results[i] = intercept.Result[string](r, 0)
+			}()
+		}
+
+		<-started
+		require.Eventually(t, func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.calls[`Fetch|"1"`].waiters == len(results)-1
+		}, time.Second, time.Millisecond, "the other callers wait for the call in progress")
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, []string{"fetched 1", "fetched 1", "fetched 1"}, results)
+		assert.Equal(t, int32(1), flaky.Calls.Load())
+	})
+
+	t.Run("expired results are evicted", func(t *testing.T) {
+		t.Parallel()
+
+		flaky := &service.Flaky{}
+		clock := &fakeClock{now: time.Now()}
+		s := NewSingleflight(time.Minute)
+		s.now = clock.Now
+		fetch := func(id string) {
+			intercept.Call(new(service.Service), flaky, nil, "Fetch", []intercept.MethodInterceptor{s}, reflect.ValueOf(context.Background()), reflect.ValueOf(id))
+		}
+
+		fetch("1")
+		fetch("2")
+		clock.Advance(time.Minute)
+		fetch("3")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		assert.Len(t, s.calls, 1)
+		assert.Contains(t, s.calls, `Fetch|"3"`)
+	})
+
+	t.Run("calls with pointer arguments are not shared", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewSingleflight(time.Hour)
+		calls := 0
+		interceptors := []intercept.MethodInterceptor{s, intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			calls++
+			return []reflect.Value{reflect.ValueOf("fetched"), reflect.Zero(reflect.TypeOf(new(error)).Elem())}
+		})}
+
+		id := "1"
+		for range 2 {
+			intercept.Call(new(service.Service), new(service.Flaky), nil, "Fetch", interceptors, reflect.ValueOf(&id))
+		}
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, s.calls)
+	})
+}
@@ -0,0 +1,69 @@
+// Code generated by dingointercept. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/intercept"
+)
+
+// ServiceProxy intercepts all methods of Service with the MethodInterceptors bound by intercept.BindMethodInterceptor,
+// or by intercept.BindAnnotatedMethodInterceptor for annotated bindings
+type ServiceProxy struct {
+	Service
+	Injector     *dingo.Injector `inject:""`
+	Interceptors []intercept.MethodInterceptor
+	element      *dingo.Element
+}
+
+// InterceptAnnotation resolves the MethodInterceptors for the annotation of the binding the proxy wraps
+func (p *ServiceProxy) InterceptAnnotation(annotation string) (err error) {
+	p.Interceptors, err = intercept.Interceptors(p.Injector, new(Service), annotation)
+	return err
+}
+
+// InterceptElement stores the multi binding or map binding element the proxy wraps
+func (p *ServiceProxy) InterceptElement(element dingo.Element) {
+	p.element = &element
+}
+
+// Fetch calls Service.Fetch through the interceptors
+func (p *ServiceProxy) Fetch(a0 context.Context, a1 string) (string, error) {
+	r := intercept.Call(new(Service), p.Service, p.element, "Fetch", p.Interceptors, reflect.ValueOf(&a0).Elem(), reflect.ValueOf(&a1).Elem())
+	return intercept.Result[string](r, 0), intercept.Result[error](r, 1)
+}
+
+// Name calls Service.Name through the interceptors
+func (p *ServiceProxy) Name() string {
+	r := intercept.Call(new(Service), p.Service, p.element, "Name", p.Interceptors)
+	return intercept.Result[string](r, 0)
+}
+
+// CounterProxy intercepts all methods of Counter with the MethodInterceptors bound by intercept.BindMethodInterceptor,
+// or by intercept.BindAnnotatedMethodInterceptor for annotated bindings
+type CounterProxy struct {
+	Counter
+	Injector     *dingo.Injector `inject:""`
+	Interceptors []intercept.MethodInterceptor
+	element      *dingo.Element
+}
+
+// InterceptAnnotation resolves the MethodInterceptors for the annotation of the binding the proxy wraps
+func (p *CounterProxy) InterceptAnnotation(annotation string) (err error) {
+	p.Interceptors, err = intercept.Interceptors(p.Injector, new(Counter), annotation)
+	return err
+}
+
+// InterceptElement stores the multi binding or map binding element the proxy wraps
+func (p *CounterProxy) InterceptElement(element dingo.Element) {
+	p.element = &element
+}
+
+// Count calls Counter.Count through the interceptors
+func (p *CounterProxy) Count() int {
+	r := intercept.Call(new(Counter), p.Counter, p.element, "Count", p.Interceptors)
+	return intercept.Result[int](r, 0)
+}
@@ -0,0 +1,50 @@
+// Package service contains an interface with a proxy generated by dingointercept, used to test the decorators.
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+//go:generate go run flamingo.me/dingo/cmd/dingointercept -o intercept_gen.go -type Service,Counter
+
+type (
+	// Service is decorated in the tests
+	Service interface {
+		Fetch(ctx context.Context, id string) (string, error)
+		Name() string
+	}
+
+	// Counter is decorated together with Service in the tests
+	Counter interface {
+		Count() int
+	}
+
+	// Flaky fails the first Failures calls of Fetch
+	Flaky struct {
+		Failures int32
+		Calls    atomic.Int32
+	}
+)
+
+// ErrFlaky is returned by Flaky
+var ErrFlaky = errors.New("flaky")
+
+// Fetch returns the id, or ErrFlaky for the first calls
+func (f *Flaky) Fetch(_ context.Context, id string) (string, error) {
+	if f.Calls.Add(1) <= f.Failures {
+		return "", ErrFlaky
+	}
+	return "fetched " + id, nil
+}
+
+// Name returns flaky
+func (f *Flaky) Name() string {
+	return "flaky"
+}
+
+// Count returns the number of Fetch calls
+func (f *Flaky) Count() int {
+	return int(f.Calls.Load())
+}
@@ -0,0 +1,48 @@
+package decorators
+
+import (
+	"log/slog"
+	"reflect"
+	"time"
+
+	"flamingo.me/dingo/intercept"
+)
+
+// Logging logs every call with its duration, failed calls are logged as error
+type Logging struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// NewLogging creates a Logging interceptor logging calls with the level, slog.Default() is used for a nil logger
+func NewLogging(logger *slog.Logger, level slog.Level) *Logging {
+	return &Logging{logger: logger, level: level}
+}
+
+// Invoke logs the call
+func (l *Logging) Invoke(ctx intercept.Invocation) []reflect.Value {
+	logger := l.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	start := time.Now()
+	results := ctx.Proceed()
+
+	attrs := []slog.Attr{
+		slog.String("interface", ctx.Interface.String()),
+		slog.String("method", ctx.Method),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if ctx.Element != nil {
+		attrs = append(attrs, slog.Int("index", ctx.Element.Index), slog.Any("key", ctx.Element.Key))
+	}
+
+	if err := errorOf(results); err != nil {
+		logger.LogAttrs(ctx.Context(), slog.LevelError, "call failed", append(attrs, slog.Any("error", err))...)
+	} else {
+		logger.LogAttrs(ctx.Context(), l.level, "call", attrs...)
+	}
+
+	return results
+}
@@ -0,0 +1,44 @@
+package decorators
+
+import (
+	"reflect"
+	"time"
+
+	"flamingo.me/dingo/intercept"
+)
+
+// Retry retries calls of methods returning an error, with an exponential backoff
+type Retry struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// NewRetry creates a Retry interceptor calling a method up to attempts times, the backoff doubles after each attempt.
+// Retrying stops when the context passed as first argument is done.
+func NewRetry(attempts int, backoff time.Duration) *Retry {
+	return &Retry{attempts: max(1, attempts), backoff: backoff}
+}
+
+// Invoke calls the method until it succeeds or the attempts are exhausted
+func (r *Retry) Invoke(ctx intercept.Invocation) []reflect.Value {
+	if !returnsError(ctx) {
+		return ctx.Proceed()
+	}
+
+	backoff := r.backoff
+	for attempt := 1; ; attempt++ {
+		results := ctx.Proceed()
+		if errorOf(results) == nil || attempt >= r.attempts {
+			return results
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Context().Done():
+			timer.Stop()
+			return results
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,96 @@
+package decorators
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"flamingo.me/dingo/intercept"
+)
+
+type (
+	// Singleflight shares the results of concurrent calls with equal arguments, and optionally memoizes successful
+	// results. It must only be used for idempotent methods.
+	// Calls are identified by value types only, calls with other arguments like pointers are not shared, see callKey.
+	Singleflight struct {
+		ttl   time.Duration
+		now   func() time.Time
+		mu    sync.Mutex
+		calls map[string]*flight
+		swept time.Time
+	}
+
+	// flight is a call in progress, or a memoized result
+	flight struct {
+		done    chan struct{}
+		waiters int // callers waiting for the call in progress
+		results []reflect.Value
+		expires time.Time
+	}
+)
+
+// NewSingleflight creates a Singleflight interceptor, results of successful calls are memoized for the ttl
+func NewSingleflight(ttl time.Duration) *Singleflight {
+	return &Singleflight{ttl: ttl, now: time.Now, calls: make(map[string]*flight)}
+}
+
+// Invoke joins a call in progress, returns a memoized result, or calls the method
+func (s *Singleflight) Invoke(ctx intercept.Invocation) []reflect.Value {
+	key, ok := callKey(ctx)
+	if !ok {
+		return ctx.Proceed()
+	}
+
+	s.mu.Lock()
+	if f, ok := s.calls[key]; ok {
+		select {
+		case <-f.done:
+			if s.now().Before(f.expires) {
+				s.mu.Unlock()
+				return f.results
+			}
+		default:
+			f.waiters++
+			s.mu.Unlock()
+			<-f.done
+			return f.results
+		}
+	}
+	s.sweep()
+	f := &flight{done: make(chan struct{})}
+	s.calls[key] = f
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if f.results == nil || errorOf(f.results) != nil || s.ttl <= 0 {
+			delete(s.calls, key)
+		} else {
+			f.expires = s.now().Add(s.ttl)
+		}
+		close(f.done)
+		s.mu.Unlock()
+	}()
+
+	f.results = ctx.Proceed()
+	return f.results
+}
+
+// sweep removes the expired results, at most once per ttl. It must be called with the lock held.
+func (s *Singleflight) sweep() {
+	now := s.now()
+	if s.ttl <= 0 || now.Sub(s.swept) < s.ttl {
+		return
+	}
+	s.swept = now
+
+	for key, f := range s.calls {
+		select {
+		case <-f.done:
+			if !now.Before(f.expires) {
+				delete(s.calls, key)
+			}
+		default:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package decorators
+
+import (
+	"encoding/json"
+	"expvar"
+	"reflect"
+	"sync"
+	"time"
+
+	"flamingo.me/dingo/intercept"
+)
+
+type (
+	// Timing records the latency of calls in one Histogram per method, published as expvar.Map
+	Timing struct {
+		methods *expvar.Map
+		mu      sync.Mutex
+	}
+
+	// Histogram counts latencies in exponential buckets from 1ms to 10s, it is an expvar.Var
+	Histogram struct {
+		mu      sync.Mutex
+		count   int64
+		sum     time.Duration
+		buckets []int64
+	}
+)
+
+// histogramBounds are the upper bounds of the histogram buckets, the last bucket counts all slower calls
+var histogramBounds = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 500 * time.Millisecond, time.Second, 5 * time.Second, 10 * time.Second,
+}
+
+// NewTiming creates a Timing published as expvar with the name, an existing expvar.Map with the name is reused
+func NewTiming(name string) *Timing {
+	if methods, ok := expvar.Get(name).(*expvar.Map); ok {
+		return &Timing{methods: methods}
+	}
+	return &Timing{methods: expvar.NewMap(name)}
+}
+
+// Invoke records the latency of the call
+func (t *Timing) Invoke(ctx intercept.Invocation) []reflect.Value {
+	start := time.Now()
+	results := ctx.Proceed()
+	t.histogram(ctx.Interface.String() + "." + ctx.Method).Observe(time.Since(start))
+	return results
+}
+
+// histogram returns the histogram of a method, it is created on first use
+func (t *Timing) histogram(method string) *Histogram {
+	if h, ok := t.methods.Get(method).(*Histogram); ok {
+		return h
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if h, ok := t.methods.Get(method).(*Histogram); ok {
+		return h
+	}
+	h := &Histogram{buckets: make([]int64, len(histogramBounds)+1)}
+	t.methods.Set(method, h)
+	return h
+}
+
+// Observe counts a latency
+func (h *Histogram) Observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.sum += d
+	for i, bound := range histogramBounds {
+		if d <= bound {
+			h.buckets[i]++
+			return
+		}
+	}
+	h.buckets[len(histogramBounds)]++
+}
+
+// String renders the histogram as JSON, with the count of calls in each bucket
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := make(map[string]int64, len(h.buckets))
+	for i, bound := range histogramBounds {
+		buckets["le_"+bound.String()] = h.buckets[i]
+	}
+	buckets["inf"] = h.buckets[len(histogramBounds)]
+
+	b, _ := json.Marshal(struct {
+		Count   int64            `json:"count"`
+		SumMs   float64          `json:"sum_ms"`
+		Buckets map[string]int64 `json:"buckets"`
+	}{Count: h.count, SumMs: float64(h.sum) / float64(time.Millisecond), Buckets: buckets})
+	return string(b)
+}
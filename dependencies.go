@@ -0,0 +1,154 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Dependency identifies a requested type together with its annotation
+type Dependency struct {
+	Type       reflect.Type
+	Annotation string
+}
+
+// String returns the type and, if present, the annotation
+func (d Dependency) String() string {
+	if d.Annotation == "" {
+		return d.Type.String()
+	}
+	return fmt.Sprintf("%s (annotated with %q)", d.Type, d.Annotation)
+}
+
+// dependencies statically determines which types are requested when the type is resolved.
+// Providers requested as dependency are called lazily, so they do not count as dependency.
+func (injector *Injector) dependencies(t reflect.Type, annotation string) []Dependency {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var deps []Dependency
+
+	if binding := injector.findBindingForAnnotatedType(t, annotation); binding != nil {
+		deps = injector.bindingDependencies(binding, t, annotation)
+	} else {
+		switch {
+		case t.Kind() == reflect.Func:
+		case t.Kind() == reflect.Slice:
+			for _, binding := range injector.joinMultibindings(elemType(t.Elem()), annotation) {
+				if !isProvider(t.Elem()) {
+					deps = append(deps, injector.bindingDependencies(binding, binding.typeof, "")...)
+				}
+			}
+		case t.Kind() == reflect.Map:
+			bindings := injector.joinMapbindings(elemType(t.Elem()), t.Key(), annotation)
+			for _, key := range sortedMapKeys(bindings) {
+				if !isProvider(t.Elem()) {
+					deps = append(deps, injector.bindingDependencies(bindings[key], bindings[key].typeof, "")...)
+				}
+			}
+		case t.Kind() == reflect.Interface || annotation != "":
+		default:
+			deps = structDependencies(t)
+		}
+	}
+
+	for _, interceptor := range injector.interceptors(t, annotation, injector.findBindingForAnnotatedType(t, annotation)) {
+		deps = append(deps, structDependencies(interceptor.interceptor)...)
+	}
+
+	return deps
+}
+
+func (injector *Injector) bindingDependencies(binding *Binding, t reflect.Type, annotation string) []Dependency {
+	switch {
+	case binding.instance != nil:
+		return nil
+	case binding.provider != nil:
+		deps := make([]Dependency, binding.provider.fnc.Type().NumIn())
+		for i := range deps {
+			deps[i] = Dependency{Type: derefType(binding.provider.fnc.Type().In(i))}
+		}
+		return deps
+	case binding.to != nil && binding.to != t:
+		return []Dependency{{Type: binding.to}}
+	case annotation != "":
+		return []Dependency{{Type: t}}
+	default:
+		return structDependencies(t)
+	}
+}
+
+// structDependencies returns the Inject method arguments and the inject-tagged fields of a type
+func structDependencies(t reflect.Type) []Dependency {
+	var deps []Dependency
+
+	if method, ok := reflect.PtrTo(t).MethodByName("Inject"); ok {
+		for i := 1; i < method.Type.NumIn(); i++ {
+			in := derefType(method.Type.In(i))
+			if in.Name() == "" && in.Kind() == reflect.Struct {
+				deps = append(deps, structDependencies(in)...)
+				continue
+			}
+			deps = append(deps, Dependency{Type: in})
+		}
+	}
+
+	if t.Kind() != reflect.Struct {
+		return deps
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("inject"); ok {
+			deps = append(deps, Dependency{Type: derefType(t.Field(i).Type), Annotation: strings.Split(tag, ",")[0]})
+		}
+	}
+
+	return deps
+}
+
+// transitiveDependencies returns all dependencies of the type in the order they are found
+func (injector *Injector) transitiveDependencies(t reflect.Type, annotation string) []Dependency {
+	var result []Dependency
+
+	seen := map[Dependency]bool{{Type: t, Annotation: annotation}: true}
+	queue := injector.dependencies(t, annotation)
+
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+
+		if seen[dep] {
+			continue
+		}
+		seen[dep] = true
+		result = append(result, dep)
+		queue = append(queue, injector.dependencies(dep.Type, dep.Annotation)...)
+	}
+
+	return result
+}
+
+// sortedMapKeys returns the keys of map bindings sorted by their formatted value
+func sortedMapKeys(bindings map[interface{}]*Binding) []interface{} {
+	keys := make([]interface{}, 0, len(bindings))
+	for key := range bindings {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return formatMapKey(keys[i]) < formatMapKey(keys[j])
+	})
+	return keys
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func isProvider(t reflect.Type) bool {
+	return t.Kind() == reflect.Func && strings.HasSuffix(t.Name(), "Provider")
+}
@@ -3,9 +3,12 @@ package dingo
 import (
 	"errors"
 	"fmt"
-	"log/slog"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -18,6 +21,7 @@ const (
 var (
 	ErrInitModules           = errors.New("initialization of modules failed")
 	ErrInvalidInjectReceiver = errors.New("usage of 'Inject' method with struct receiver is not allowed")
+	ErrInvalidBinding        = errors.New("invalid binding")
 	errPointersToInterface   = errors.New(" Do not use pointers to interface")
 
 	traceCircular    []circularTraceEntry
@@ -26,10 +30,15 @@ var (
 
 // EnableCircularTracing activates dingo's trace feature to find circular dependencies
 // this is super expensive (memory wise), so it should only be used for debugging purposes
+//
+// Deprecated: this affects all injectors of the process, use NewInjectorWithOptions with WithCircularTracing instead.
 func EnableCircularTracing() {
 	traceCircular = make([]circularTraceEntry, 0)
 }
 
+// EnableInjectionTracing logs every just in time created type and every set field
+//
+// Deprecated: this affects all injectors of the process, use NewInjectorWithOptions with WithInjectionTracing instead.
 func EnableInjectionTracing() {
 	injectionTracing = true
 }
@@ -38,16 +47,25 @@ type (
 	// Injector defines bindings and multibindings
 	// it is possible to have a parent-injector, which can be asked if no resolution is available
 	Injector struct {
-		bindings             map[reflect.Type][]*Binding          // list of available bindings for a concrete type
-		multibindings        map[reflect.Type][]*Binding          // list of multi-bindings for a concrete type
-		mapbindings          map[reflect.Type]map[string]*Binding // list of map-bindings for a concrete type
-		interceptor          map[reflect.Type][]reflect.Type      // list of interceptors for a type
-		overrides            []*override                          // list of overrides for a binding
-		parent               *Injector                            // parent injector reference
-		scopes               map[reflect.Type]Scope               // scope-bindings
-		stage                uint                                 // current stage
-		delayed              []interface{}                        // delayed bindings
-		buildEagerSingletons bool                                 // whether to build singletons
+		bindings         map[reflect.Type][]*Binding               // list of available bindings for a concrete type
+		multibindings    map[reflect.Type][]*Binding               // list of multi-bindings for a concrete type
+		mapbindings      map[reflect.Type]map[interface{}]*Binding // list of map-bindings for a concrete type
+		sets             map[reflect.Type]bool                     // multi binding types bound with set semantics
+		interceptor      map[reflect.Type][]*InterceptorBinding    // list of interceptors for a type
+		decorators       map[reflect.Type][]*decorator             // list of decorators for a type
+		overrides        []*override                               // list of overrides for a binding
+		elementOverrides []*elementOverride                        // list of overrides for map and multi bindings
+		mapDuplicates    []*mapDuplicate                           // map bindings registered for an already bound key
+		parent           *Injector                                 // parent injector reference
+		scopes           map[reflect.Type]Scope                    // scope-bindings
+		stage            uint                                      // current stage
+		delayed          []interface{}                             // delayed bindings
+		modules          []Module                                  // initialized modules in order
+		observers        []Observer                                // observers notified about resolution events
+		options          options                                   // injector options, inherited by children
+		initReport       *InitReport                               // report of the last eager singleton construction
+		asyncMu          sync.Mutex                                // guards async
+		async            map[*Binding]*asyncSingleton              // async eager singletons under construction
 	}
 
 	// overrides are evaluated lazy, so they are scheduled here
@@ -65,16 +83,39 @@ type (
 
 // NewInjector builds up a new Injector out of a list of Modules
 func NewInjector(modules ...Module) (*Injector, error) {
+	return NewInjectorWithOptions(WithModules(modules...))
+}
+
+// NewInjectorWithOptions builds up a new Injector configured by the given options
+func NewInjectorWithOptions(opts ...Option) (*Injector, error) {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return newInjector(options)
+}
+
+func newInjector(options options) (*Injector, error) {
 	injector := &Injector{
-		bindings:             make(map[reflect.Type][]*Binding),
-		multibindings:        make(map[reflect.Type][]*Binding),
-		mapbindings:          make(map[reflect.Type]map[string]*Binding),
-		interceptor:          make(map[reflect.Type][]reflect.Type),
-		scopes:               make(map[reflect.Type]Scope),
-		stage:                DEFAULT,
-		buildEagerSingletons: true,
+		bindings:      make(map[reflect.Type][]*Binding),
+		multibindings: make(map[reflect.Type][]*Binding),
+		mapbindings:   make(map[reflect.Type]map[interface{}]*Binding),
+		sets:          make(map[reflect.Type]bool),
+		interceptor:   make(map[reflect.Type][]*InterceptorBinding),
+		scopes:        make(map[reflect.Type]Scope),
+		stage:         DEFAULT,
+		observers:     slices.Clone(options.observers),
+		options:       options,
 	}
 
+	modules := options.modules
+	injector.options.modules = nil
+
+	// the deprecated global switches are read once, the injector only uses its options afterwards
+	injector.options.circularTracing = options.circularTracing || traceCircular != nil
+	injector.options.injectionTracing = options.injectionTracing || injectionTracing
+
 	// bind current injector
 	injector.Bind(Injector{}).ToInstance(injector)
 
@@ -86,13 +127,16 @@ func NewInjector(modules ...Module) (*Injector, error) {
 	return injector, injector.InitModules(modules...)
 }
 
-// Child derives a child injector with a new ChildSingletonScope
+// Child derives a child injector with a new ChildSingletonScope, inheriting the options of the injector
 func (injector *Injector) Child() (*Injector, error) {
 	if injector == nil {
 		return nil, errors.New("can not create a child of an uninitialized injector")
 	}
 
-	newInjector, err := NewInjector()
+	options := injector.options
+	options.observers = injector.observers
+
+	newInjector, err := newInjector(options)
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +164,10 @@ func (injector *Injector) InitModules(modules ...Module) error {
 		return fmt.Errorf("%w: failed sorting modules: %w", ErrInitModules, err)
 	}
 
+	injector.modules = append(injector.modules, modules...)
+
 	for _, module := range modules {
-		if err := injector.requestInjection(module, traceCircular); err != nil {
+		if err := injector.requestInjection(module, injector.circularTrace()); err != nil {
 			erroredModule := reflect.TypeOf(module).Elem()
 			return fmt.Errorf("initmodules: injection into %q failed: %w", erroredModule.PkgPath()+"."+erroredModule.Name(), err)
 		}
@@ -145,6 +191,10 @@ func (injector *Injector) InitModules(modules ...Module) error {
 		return fmt.Errorf("cannot override unknown binding %q (annotated with %q)", override.typ.String(), override.annotatedWith) // todo ok?
 	}
 
+	if err := injector.applyElementOverrides(); err != nil {
+		return err
+	}
+
 	// make sure there are no duplicated bindings
 	for typ, bindings := range injector.bindings {
 		known := make(map[string]*Binding)
@@ -163,11 +213,17 @@ func (injector *Injector) InitModules(modules ...Module) error {
 		}
 	}
 
+	injector.deduplicateMultibindings()
+
+	if err := injector.checkMultibindingOrder(); err != nil {
+		return err
+	}
+
 	injector.stage = DEFAULT
 
 	// continue with delayed injections
 	for _, object := range injector.delayed {
-		if err := injector.requestInjection(object, traceCircular); err != nil {
+		if err := injector.requestInjection(object, injector.circularTrace()); err != nil {
 			return err
 		}
 	}
@@ -175,7 +231,7 @@ func (injector *Injector) InitModules(modules ...Module) error {
 	injector.delayed = nil
 
 	// build eager singletons
-	if !injector.buildEagerSingletons {
+	if !injector.options.buildEagerSingletons {
 		return nil
 	}
 	return injector.BuildEagerSingletons(false)
@@ -183,29 +239,12 @@ func (injector *Injector) InitModules(modules ...Module) error {
 
 // SetBuildEagerSingletons can be used to disable or enable building of eager singletons during InitModules
 func (injector *Injector) SetBuildEagerSingletons(build bool) {
-	injector.buildEagerSingletons = build
-}
-
-// BuildEagerSingletons requests one instance of each singleton, optional letting the parent injector(s) do the same
-func (injector *Injector) BuildEagerSingletons(includeParent bool) error {
-	for _, bindings := range injector.bindings {
-		for _, binding := range bindings {
-			if binding.eager {
-				if _, err := injector.getInstance(binding.typeof, binding.annotatedWith, traceCircular); err != nil {
-					return fmt.Errorf("initmodules: loading eager singletons: %w", err)
-				}
-			}
-		}
-	}
-	if includeParent && injector.parent != nil {
-		return injector.parent.BuildEagerSingletons(includeParent)
-	}
-	return nil
+	injector.options.buildEagerSingletons = build
 }
 
 // GetInstance creates a new instance of what was requested
 func (injector *Injector) GetInstance(of interface{}) (interface{}, error) {
-	i, err := injector.getInstance(of, "", traceCircular)
+	i, err := injector.getInstance(of, "", injector.circularTrace())
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +253,7 @@ func (injector *Injector) GetInstance(of interface{}) (interface{}, error) {
 
 // GetAnnotatedInstance creates a new instance of what was requested with the given annotation
 func (injector *Injector) GetAnnotatedInstance(of interface{}, annotatedWith string) (interface{}, error) {
-	i, err := injector.getInstance(of, annotatedWith, traceCircular)
+	i, err := injector.getInstance(of, annotatedWith, injector.circularTrace())
 	if err != nil {
 		return nil, err
 	}
@@ -223,17 +262,21 @@ func (injector *Injector) GetAnnotatedInstance(of interface{}, annotatedWith str
 
 // getInstance creates the new instance of typ, returns a reflect.value
 func (injector *Injector) getInstance(typ interface{}, annotatedWith string, circularTrace []circularTraceEntry) (reflect.Value, error) {
-	oftype := reflect.TypeOf(typ)
+	return injector.getInstanceOfTypeWithAnnotation(typeOf(typ), annotatedWith, nil, false, circularTrace)
+}
 
+// typeOf returns the requested type, a reflect.Type is taken as is, otherwise all pointers are dereferenced
+func typeOf(typ interface{}) reflect.Type {
 	if oft, ok := typ.(reflect.Type); ok {
-		oftype = oft
-	} else {
-		for oftype.Kind() == reflect.Ptr {
-			oftype = oftype.Elem()
-		}
+		return oft
 	}
 
-	return injector.getInstanceOfTypeWithAnnotation(oftype, annotatedWith, nil, false, circularTrace)
+	oftype := reflect.TypeOf(typ)
+	for oftype.Kind() == reflect.Ptr {
+		oftype = oftype.Elem()
+	}
+
+	return oftype
 }
 
 func (injector *Injector) findBindingForAnnotatedType(t reflect.Type, annotation string) *Binding {
@@ -264,25 +307,81 @@ func (injector *Injector) getInstanceOfTypeWithAnnotation(t reflect.Type, annota
 		t = t.Elem()
 	}
 
-	var final reflect.Value
-	var err error
-
 	if typeBinding := injector.findBindingForAnnotatedType(t, annotation); typeBinding != nil {
 		binding = typeBinding
 	}
+
+	if err := injector.checkStrict(t, annotation, binding, optional); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if binding != nil && binding.async && binding.typeof == t && binding.annotatedWith == annotation {
+		if err := injector.awaitAsync(binding); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	if len(injector.observers) == 0 {
+		return injector.resolveInstance(t, annotation, binding, optional, circularTrace)
+	}
+
+	event := Event{Kind: EventResolveStart, Type: t, Annotation: annotation, BindingKind: bindingKindOf(t, binding)}
+	if binding != nil {
+		event.Scope = binding.scope
+	}
+	injector.observe(event)
+
+	start := time.Now()
+	final, err := injector.resolveInstance(t, annotation, binding, optional, circularTrace)
+
+	event.Kind = EventResolveEnd
+	event.Duration = time.Since(start)
+	event.Err = err
+	injector.observe(event)
+
+	return final, err
+}
+
+// resolveInstance resolves a requested type in the binding's scope, and applies the interceptors
+func (injector *Injector) resolveInstance(t reflect.Type, annotation string, binding *Binding, optional bool, circularTrace []circularTraceEntry) (reflect.Value, error) {
+	final, err := injector.createScopedInstance(t, annotation, binding, optional, circularTrace)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if final, err = injector.intercept(final, t, annotation, binding); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return injector.decorate(final, t, circularTrace)
+}
+
+// createScopedInstance resolves a requested type in the binding's scope, without interceptors
+func (injector *Injector) createScopedInstance(t reflect.Type, annotation string, binding *Binding, optional bool, circularTrace []circularTraceEntry) (reflect.Value, error) {
+	var final reflect.Value
+	var err error
+
 	if binding != nil {
 		if binding.scope != nil {
 			if scope, ok := injector.scopes[reflect.TypeOf(binding.scope)]; ok {
 				if final, err = scope.ResolveType(t, annotation, func(t reflect.Type, annotation string, optional bool) (reflect.Value, error) {
-					return injector.createInstanceOfAnnotatedType(t, annotation, optional, circularTrace)
+					if len(injector.observers) == 0 {
+						return injector.createInstanceOfAnnotatedType(t, annotation, optional, circularTrace)
+					}
+
+					start := time.Now()
+					instance, err := injector.createInstanceOfAnnotatedType(t, annotation, optional, circularTrace)
+					injector.observe(Event{Kind: EventSingletonCreated, Type: t, Annotation: annotation, Scope: scope, BindingKind: bindingKindOf(t, binding), Duration: time.Since(start), Err: err})
+
+					return instance, err
 				}); err != nil {
 					return reflect.Value{}, err
 				}
 				if !final.IsValid() {
-					return reflect.Value{}, fmt.Errorf("%T did not resolve %s", scope, t)
+					return reflect.Value{}, &ScopeError{Type: t, Annotation: annotation, Scope: scope, message: fmt.Sprintf("%T did not resolve %s", scope, t)}
 				}
 			} else {
-				return reflect.Value{}, fmt.Errorf("unknown scope %T for %s", binding.scope, t)
+				return reflect.Value{}, &ScopeError{Type: t, Annotation: annotation, Scope: binding.scope, message: fmt.Sprintf("unknown scope %T for %s", binding.scope, t)}
 			}
 		}
 	}
@@ -297,33 +396,9 @@ func (injector *Injector) getInstanceOfTypeWithAnnotation(t reflect.Type, annota
 		return reflect.Value{}, fmt.Errorf("can not resolve %q", t.String())
 	}
 
-	return injector.intercept(final, t)
-}
-
-func (injector *Injector) intercept(final reflect.Value, t reflect.Type) (reflect.Value, error) {
-	for _, interceptor := range injector.interceptor[t] {
-		of := final
-		final = reflect.New(interceptor)
-		if err := injector.requestInjection(final.Interface(), traceCircular); err != nil {
-			return reflect.Value{}, err
-		}
-		final.Elem().Field(0).Set(of)
-	}
-	if injector.parent != nil {
-		return injector.parent.intercept(final, t)
-	}
 	return final, nil
 }
 
-type errUnbound struct {
-	binding *Binding
-	typ     reflect.Type
-}
-
-func (err errUnbound) Error() string {
-	return fmt.Sprintf("binding is not bound: %v for %s", err.binding, err.typ)
-}
-
 func (injector *Injector) resolveBinding(binding *Binding, t reflect.Type, optional bool, circularTrace []circularTraceEntry) (reflect.Value, error) {
 	if binding.instance != nil {
 		return binding.instance.ivalue, nil
@@ -335,19 +410,20 @@ func (injector *Injector) resolveBinding(binding *Binding, t reflect.Type, optio
 
 	if binding.to != nil {
 		if binding.to == t {
-			return reflect.Value{}, fmt.Errorf("circular from %q to %q (annotated with: %q)", t, binding.to, binding.annotatedWith)
+			return reflect.Value{}, &CycleError{Type: t, Annotation: binding.annotatedWith, message: fmt.Sprintf("circular from %q to %q (annotated with: %q)", t, binding.to, binding.annotatedWith)}
 		}
 		return injector.getInstanceOfTypeWithAnnotation(binding.to, "", binding, optional, circularTrace)
 	}
 
-	return reflect.Value{}, errUnbound{binding: binding, typ: t}
+	return reflect.Value{}, &UnboundError{Type: t, Annotation: binding.annotatedWith, binding: binding, message: fmt.Sprintf("binding is not bound: %v for %s", binding, t)}
 }
 
 // createInstanceOfAnnotatedType resolves a type request with the current injector
 func (injector *Injector) createInstanceOfAnnotatedType(t reflect.Type, annotation string, optional bool, circularTrace []circularTraceEntry) (reflect.Value, error) {
 	if binding := injector.findBindingForAnnotatedType(t, annotation); binding != nil {
 		r, err := injector.resolveBinding(binding, t, optional, circularTrace)
-		if err == nil || !errors.As(err, new(errUnbound)) {
+		// only an untargeted binding itself falls back, not an unbound dependency
+		if unbound := (*UnboundError)(nil); err == nil || !errors.As(err, &unbound) || unbound.binding != binding {
 			return r, err
 		}
 
@@ -360,7 +436,7 @@ func (injector *Injector) createInstanceOfAnnotatedType(t reflect.Type, annotati
 	// This for an injection request on a provider, such as `func() MyInstance`
 	if t.Kind() == reflect.Func && (t.NumOut() == 1 || t.NumOut() == 2) && strings.HasSuffix(t.Name(), "Provider") {
 		providerCanError := t.NumOut() == 2 && t.Out(1).AssignableTo(reflect.TypeOf(new(error)).Elem())
-		if traceCircular != nil {
+		if injector.circularTracing() {
 			return injector.createProvider(t, annotation, optional, providerCanError, make([]circularTraceEntry, 0)), nil
 		}
 		return injector.createProvider(t, annotation, optional, providerCanError, nil), nil
@@ -372,32 +448,37 @@ func (injector *Injector) createInstanceOfAnnotatedType(t reflect.Type, annotati
 	}
 
 	// Map Binding injection
-	if t.Kind() == reflect.Map && t.Key().Kind() == reflect.String {
+	if t.Kind() == reflect.Map {
 		return injector.resolveMapbinding(t, annotation, optional, circularTrace)
 	}
 
+	// lazy iteration over multi bindings and map bindings
+	if seq, ok := iterSeq(t); ok {
+		return injector.resolveSeq(t, seq, annotation, optional), nil
+	}
+
 	if annotation != "" && !optional {
-		return reflect.Value{}, fmt.Errorf("can not automatically create an annotated injection %q with annotation %q", t, annotation)
+		return reflect.Value{}, injector.annotationNotFound(t, annotation)
 	}
 
 	if t.Kind() == reflect.Interface && !optional {
-		return reflect.Value{}, fmt.Errorf("can not instantiate interface %s.%s", t.PkgPath(), t.Name())
+		return reflect.Value{}, injector.unboundInterface(t)
 	}
 
 	if t.Kind() == reflect.Func && !optional {
-		return reflect.Value{}, fmt.Errorf("can not create a new function %q (Do you want a provider? Then suffix type with Provider)", t)
+		return reflect.Value{}, &UnboundError{Type: t, message: fmt.Sprintf("can not create a new function %q (Do you want a provider? Then suffix type with Provider)", t)}
 	}
 
 	if circularTrace != nil {
 		for _, ct := range circularTrace {
 			if ct.typ == t && ct.annotation == annotation {
 				for _, ct := range circularTrace {
-					slog.Info(fmt.Sprintf("%s#%s: %s", ct.typ.PkgPath(), ct.typ.Name(), ct.annotation))
+					injector.logger().Info(fmt.Sprintf("%s#%s: %s", ct.typ.PkgPath(), ct.typ.Name(), ct.annotation))
 				}
 
-				slog.Info(fmt.Sprintf("%s#%s: %s", t.PkgPath(), t.Name(), annotation))
+				injector.logger().Info(fmt.Sprintf("%s#%s: %s", t.PkgPath(), t.Name(), annotation))
 
-				panic("detected circular dependency")
+				panic(&CycleError{Type: t, Annotation: annotation, message: "detected circular dependency"})
 			}
 		}
 		subCircularTrace := make([]circularTraceEntry, len(circularTrace))
@@ -408,11 +489,11 @@ func (injector *Injector) createInstanceOfAnnotatedType(t reflect.Type, annotati
 		return n, injector.requestInjection(n.Interface(), subCircularTrace)
 	}
 
-	if injectionTracing {
+	if injector.injectionTracing() {
 		if t.PkgPath() == "" || t.Name() == "" {
-			slog.Info(fmt.Sprintf("INJECTING: %s", t.String()))
+			injector.logger().Info(fmt.Sprintf("INJECTING: %s", t.String()))
 		} else {
-			slog.Info(fmt.Sprintf("INJECTING: %s#%s \"%s\"", t.PkgPath(), t.Name(), annotation))
+			injector.logger().Info(fmt.Sprintf("INJECTING: %s#%s \"%s\"", t.PkgPath(), t.Name(), annotation))
 		}
 	}
 
@@ -440,7 +521,7 @@ func (injector *Injector) createProvider(t reflect.Type, annotation string, opti
 
 		ret := func(v reflect.Value, err error) []reflect.Value {
 			if err != nil && !canError {
-				panic(fmt.Errorf("%q: %w", t, err))
+				return injector.providerFailed(t, err)
 			} else if canError {
 				return []reflect.Value{v, reflectedError(&err, t)}
 			} else {
@@ -454,7 +535,7 @@ func (injector *Injector) createProvider(t reflect.Type, annotation string, opti
 		}
 
 		// mapbindings
-		if res.Elem().Kind() == reflect.Map && res.Elem().Type().Key().Kind() == reflect.String {
+		if res.Elem().Kind() == reflect.Map {
 			return ret(injector.createInstanceOfAnnotatedType(t.Out(0), annotation, optional, circularTrace))
 		}
 
@@ -467,7 +548,10 @@ func (injector *Injector) createProvider(t reflect.Type, annotation string, opti
 	})
 }
 
-func (injector *Injector) createProviderForBinding(t reflect.Type, binding *Binding, annotation string, optional bool, canError bool, circularTrace []circularTraceEntry) reflect.Value {
+// createProviderForBinding creates a provider of a multi binding or map binding element, which is intercepted and
+// decorated like the element itself
+func (injector *Injector) createProviderForBinding(t reflect.Type, binding *Binding, element *Element, optional bool, canError bool, circularTrace []circularTraceEntry) reflect.Value {
+	annotation := element.Annotation
 	return reflect.MakeFunc(t, func(args []reflect.Value) (results []reflect.Value) {
 		// create a new type
 		res := reflect.New(binding.typeof)
@@ -476,7 +560,7 @@ func (injector *Injector) createProviderForBinding(t reflect.Type, binding *Bind
 			res = res.Elem()
 		}
 
-		if r, err := injector.resolveBinding(binding, t, optional, circularTrace); err == nil {
+		if r, err := injector.resolveElement(binding, t, binding.typeof, element, optional, circularTrace); err == nil {
 			res.Set(r)
 			if canError {
 				return []reflect.Value{res, reflectedError(nil, t)}
@@ -490,7 +574,7 @@ func (injector *Injector) createProviderForBinding(t reflect.Type, binding *Bind
 			if canError {
 				return []reflect.Value{res, reflectedError(&err, t)}
 			}
-			panic(fmt.Errorf("%q: %w", t, err))
+			return injector.providerFailed(t, err)
 		}
 		res.Set(i)
 		// return
@@ -501,10 +585,11 @@ func (injector *Injector) createProviderForBinding(t reflect.Type, binding *Bind
 	})
 }
 
-func (injector *Injector) joinMultibindings(t reflect.Type, annotation string) []*Binding {
+// collectMultibindings returns the multi bindings of the parents and the injector in registration order
+func (injector *Injector) collectMultibindings(t reflect.Type, annotation string) []*Binding {
 	var parent []*Binding
 	if injector.parent != nil {
-		parent = injector.parent.joinMultibindings(t, annotation)
+		parent = injector.parent.collectMultibindings(t, annotation)
 	}
 
 	bindings := make([]*Binding, len(parent)+len(injector.multibindings[t]))
@@ -535,13 +620,13 @@ func (injector *Injector) resolveMultibinding(t reflect.Type, annotation string,
 
 	if bindings := injector.joinMultibindings(targetType, annotation); len(bindings) > 0 {
 		n := reflect.MakeSlice(t, 0, len(bindings))
-		for _, binding := range bindings {
+		for i, binding := range bindings {
 			if provider {
-				n = reflect.Append(n, injector.createProviderForBinding(providerType, binding, annotation, false, providerCanError, circularTrace))
+				n = reflect.Append(n, injector.createProviderForBinding(providerType, binding, &Element{Annotation: annotation, Index: i}, false, providerCanError, circularTrace))
 				continue
 			}
 
-			r, err := injector.resolveBinding(binding, t, optional, circularTrace)
+			r, err := injector.resolveElement(binding, t, targetType, &Element{Annotation: annotation, Index: i}, optional, circularTrace)
 			if err != nil {
 				return reflect.Value{}, err
 			}
@@ -553,18 +638,32 @@ func (injector *Injector) resolveMultibinding(t reflect.Type, annotation string,
 	return reflect.MakeSlice(t, 0, 0), nil
 }
 
-func (injector *Injector) joinMapbindings(t reflect.Type, annotation string) map[string]*Binding {
-	var parent map[string]*Binding
+// resolveElement resolves an element of a multi binding or map binding, and intercepts and decorates it
+func (injector *Injector) resolveElement(binding *Binding, t reflect.Type, targetType reflect.Type, element *Element, optional bool, circularTrace []circularTraceEntry) (reflect.Value, error) {
+	r, err := injector.resolveBinding(binding, t, optional, circularTrace)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if r, err = injector.interceptElement(r, targetType, element.Annotation, binding, element); err != nil {
+		return reflect.Value{}, err
+	}
+	return injector.decorate(r, targetType, circularTrace)
+}
+
+// joinMapbindings returns the map bindings of the type with keys assignable to the key type, child bindings replace
+// parent bindings with the same key
+func (injector *Injector) joinMapbindings(t reflect.Type, key reflect.Type, annotation string) map[interface{}]*Binding {
+	var parent map[interface{}]*Binding
 	if injector.parent != nil {
-		parent = injector.parent.joinMapbindings(t, annotation)
+		parent = injector.parent.joinMapbindings(t, key, annotation)
 	}
 
-	bindings := make(map[string]*Binding, len(parent)+len(injector.multibindings[t]))
+	bindings := make(map[interface{}]*Binding, len(parent)+len(injector.mapbindings[t]))
 	for k, v := range parent {
 		bindings[k] = v
 	}
 	for k, v := range injector.mapbindings[t] {
-		if v.annotatedWith == annotation {
+		if v.annotatedWith == annotation && reflect.TypeOf(k).AssignableTo(key) {
 			bindings[k] = v
 		}
 	}
@@ -585,15 +684,15 @@ func (injector *Injector) resolveMapbinding(t reflect.Type, annotation string, o
 		targetType = targetType.Out(0)
 	}
 
-	if bindings := injector.joinMapbindings(targetType, annotation); len(bindings) > 0 {
+	if bindings := injector.joinMapbindings(targetType, t.Key(), annotation); len(bindings) > 0 {
 		n := reflect.MakeMapWithSize(t, len(bindings))
 		for key, binding := range bindings {
 			if provider {
-				n.SetMapIndex(reflect.ValueOf(key), injector.createProviderForBinding(providerType, binding, annotation, false, providerCanError, circularTrace))
+				n.SetMapIndex(reflect.ValueOf(key), injector.createProviderForBinding(providerType, binding, &Element{Annotation: annotation, Index: -1, Key: key}, false, providerCanError, circularTrace))
 				continue
 			}
 
-			r, err := injector.resolveBinding(binding, t, optional, circularTrace)
+			r, err := injector.resolveElement(binding, t, targetType, &Element{Annotation: annotation, Index: -1, Key: key}, optional, circularTrace)
 			if err != nil {
 				return reflect.Value{}, err
 			}
@@ -607,48 +706,60 @@ func (injector *Injector) resolveMapbinding(t reflect.Type, annotation string, o
 
 // BindMulti binds multiple concrete types to the same abstract type / interface
 func (injector *Injector) BindMulti(what interface{}) *Binding {
-	bindtype := reflect.TypeOf(what)
-	if bindtype.Kind() == reflect.Ptr {
-		bindtype = bindtype.Elem()
+	binding, err := injector.TryBindMulti(what)
+	if err != nil {
+		panic(err)
+	}
+	return binding
+}
+
+// TryBindMulti is BindMulti, returning an ErrInvalidBinding error instead of panicking
+func (injector *Injector) TryBindMulti(what interface{}) (*Binding, error) {
+	bindtype, err := bindType(what)
+	if err != nil {
+		return nil, err
 	}
 	binding := new(Binding)
 	binding.typeof = bindtype
 	imb := injector.multibindings[bindtype]
 	imb = append(imb, binding)
 	injector.multibindings[bindtype] = imb
+	return binding, nil
+}
+
+// BindMap does a registry-like map-based binding, like BindMulti.
+// The key can be of any comparable type, the binding is part of all injected maps with a key type it is assignable to.
+// Binding a key twice with different targets fails in InitModules, use OverrideMap to replace a map binding.
+func (injector *Injector) BindMap(what interface{}, key interface{}) *Binding {
+	binding, err := injector.TryBindMap(what, key)
+	if err != nil {
+		panic(err)
+	}
 	return binding
 }
 
-// BindMap does a registry-like map-based binding, like BindMulti
-func (injector *Injector) BindMap(what interface{}, key string) *Binding {
-	bindtype := reflect.TypeOf(what)
-	if bindtype.Kind() == reflect.Ptr {
-		bindtype = bindtype.Elem()
+// TryBindMap is BindMap, returning an ErrInvalidBinding error instead of panicking
+func (injector *Injector) TryBindMap(what interface{}, key interface{}) (*Binding, error) {
+	bindtype, err := bindType(what)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMapKey(key); err != nil {
+		return nil, err
 	}
 	binding := new(Binding)
 	binding.typeof = bindtype
 	bindingMap := injector.mapbindings[bindtype]
 	if bindingMap == nil {
-		bindingMap = make(map[string]*Binding)
+		bindingMap = make(map[interface{}]*Binding)
+	}
+	if known, ok := bindingMap[key]; ok {
+		injector.mapDuplicates = append(injector.mapDuplicates, &mapDuplicate{key: key, known: known, binding: binding})
 	}
 	bindingMap[key] = binding
 	injector.mapbindings[bindtype] = bindingMap
 
-	return binding
-}
-
-// BindInterceptor intercepts to interface with interceptor
-func (injector *Injector) BindInterceptor(to, interceptor interface{}) {
-	totype := reflect.TypeOf(to)
-	if totype.Kind() == reflect.Ptr {
-		totype = totype.Elem()
-	}
-	if totype.Kind() != reflect.Interface {
-		panic("can only intercept interfaces " + fmt.Sprintf("%v", to))
-	}
-	m := injector.interceptor[totype]
-	m = append(m, reflect.TypeOf(interceptor))
-	injector.interceptor[totype] = m
+	return binding, nil
 }
 
 // BindScope binds a scope to be aware of
@@ -663,17 +774,55 @@ func (injector *Injector) BindScope(s Scope) {
 //
 // To specify the interface (cast it to a pointer to a nil of the type Interface)
 func (injector *Injector) Bind(what interface{}) *Binding {
+	binding, err := injector.TryBind(what)
+	if err != nil {
+		panic(err)
+	}
+	return binding
+}
+
+// TryBind is Bind, returning an ErrInvalidBinding error instead of panicking.
+// Together with Binding.TryTo, TryToInstance and TryToProvider bindings can be built from untrusted input.
+func (injector *Injector) TryBind(what interface{}) (*Binding, error) {
+	bindtype, err := bindType(what)
+	if err != nil {
+		return nil, err
+	}
+	binding := new(Binding)
+	binding.typeof = bindtype
+	injector.bindings[bindtype] = append(injector.bindings[bindtype], binding)
+	return binding, nil
+}
+
+// bindType returns the type to bind, dereferencing one pointer
+func bindType(what interface{}) (reflect.Type, error) {
 	if what == nil {
-		panic("Cannot bind nil")
+		return nil, fmt.Errorf("%w: cannot bind nil", ErrInvalidBinding)
 	}
 	bindtype := reflect.TypeOf(what)
 	if bindtype.Kind() == reflect.Ptr {
 		bindtype = bindtype.Elem()
 	}
-	binding := new(Binding)
-	binding.typeof = bindtype
-	injector.bindings[bindtype] = append(injector.bindings[bindtype], binding)
-	return binding
+	return bindtype, nil
+}
+
+// checkMapKey makes sure the key of a map binding can be used as map key
+func checkMapKey(key interface{}) error {
+	if key == nil {
+		return fmt.Errorf("%w: map binding key is nil", ErrInvalidBinding)
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		return fmt.Errorf("%w: map binding key of type %s is not comparable", ErrInvalidBinding, reflect.TypeOf(key))
+	}
+	return nil
+}
+
+// formatMapKey formats the key of a map binding for messages, string keys are quoted
+func formatMapKey(key interface{}) string {
+	if key, ok := key.(string); ok {
+		return strconv.Quote(key)
+	}
+	return fmt.Sprintf("%v", key)
 }
 
 // Override a binding
@@ -688,7 +837,7 @@ func (injector *Injector) RequestInjection(object interface{}) error {
 	if injector.stage == INIT {
 		injector.delayed = append(injector.delayed, object)
 	} else {
-		return injector.requestInjection(object, traceCircular)
+		return injector.requestInjection(object, injector.circularTrace())
 	}
 	return nil
 }
@@ -715,6 +864,14 @@ func (injector *Injector) requestInjection(object interface{}, circularTrace []c
 		return fmt.Errorf("injecting into %s%s:\n%w", path, current.String(), err)
 	}
 
+	// wrapPoint adds the injection point to the path of typed resolution errors, and wraps all others
+	wrapPoint := func(err error, point InjectionPoint) error {
+		if err, ok := withInjectionPoint(err, point); ok {
+			return err
+		}
+		return wrapErr(err)
+	}
+
 	for {
 		if i >= len(injectlist) {
 			break
@@ -736,10 +893,15 @@ func (injector *Injector) requestInjection(object interface{}, circularTrace []c
 				args := make([]reflect.Value, setup.Type().NumIn())
 				for i := range args {
 					if args[i], err = injector.getInstance(setup.Type().In(i), "", circularTrace); err != nil {
-						return wrapErr(err)
+						return wrapPoint(err, InjectionPoint{Kind: InjectionPointInjectArgument, Owner: ctype.Elem(), Parameter: i, Type: setup.Type().In(i)})
 					}
 				}
-				setup.Call(args)
+				injector.profile(profileInject, ctype.Elem(), "", func() {
+					err = injector.recoverPanic(ctype.Elem(), "", ctype.String()+".Inject", func() { setup.Call(args) })
+				})
+				if err != nil {
+					return err
+				}
 			}
 			injectlist = append(injectlist, current.Elem())
 
@@ -764,7 +926,7 @@ func (injector *Injector) requestInjection(object interface{}, circularTrace []c
 
 					instance, err := injector.getInstanceOfTypeWithAnnotation(field.Type(), tag, nil, optional, circularTrace)
 					if err != nil {
-						return wrapErr(err)
+						return wrapPoint(err, InjectionPoint{Kind: InjectionPointField, Owner: ctype, Field: currentFieldName, Type: field.Type(), Annotation: tag})
 					}
 					if instance.Kind() == reflect.Ptr {
 						if instance.Elem().Kind() == reflect.Func || instance.Elem().Kind() == reflect.Interface || instance.Elem().Kind() == reflect.Slice {
@@ -772,8 +934,8 @@ func (injector *Injector) requestInjection(object interface{}, circularTrace []c
 						}
 					}
 					if field.Kind() != reflect.Ptr && field.Kind() != reflect.Interface && instance.Kind() == reflect.Ptr {
-						if injectionTracing {
-							slog.Info(fmt.Sprintf("SETTING FIELD: %s of type \"%s\"", currentFieldName, ctype.Field(fieldIndex).Type.String()))
+						if injector.injectionTracing() {
+							injector.logger().Info(fmt.Sprintf("SETTING FIELD: %s of type \"%s\"", currentFieldName, ctype.Field(fieldIndex).Type.String()))
 						}
 
 						field.Set(instance.Elem())
@@ -782,8 +944,8 @@ func (injector *Injector) requestInjection(object interface{}, circularTrace []c
 							return wrapErr(fmt.Errorf("field %#v is pointer to interface. %w", currentFieldName, errPointersToInterface))
 						}
 
-						if injectionTracing {
-							slog.Info(fmt.Sprintf("SETTING FIELD: %s of type \"%s\"", currentFieldName, ctype.Field(fieldIndex).Type.String()))
+						if injector.injectionTracing() {
+							injector.logger().Info(fmt.Sprintf("SETTING FIELD: %s of type \"%s\"", currentFieldName, ctype.Field(fieldIndex).Type.String()))
 						}
 
 						field.Set(instance)
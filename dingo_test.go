@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type (
@@ -351,3 +352,33 @@ func TestInjectionOfInterfacePointer(t *testing.T) {
 	_, err = injector.GetInstance(new(someStructWithInvalidInterfacePointer))
 	assert.Error(t, err, "Expected error")
 }
+
+func TestInjector_TryBind(t *testing.T) {
+	injector, err := NewInjector()
+	require.NoError(t, err)
+
+	_, err = injector.TryBind(nil)
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindMulti(nil)
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindMap(nil, "key")
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+
+	_, err = injector.TryBindInterceptor(new(string), AopInterceptor1{})
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindInterceptor(new(AopInterface), new(AopInterceptor1))
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindInterceptor(new(AopInterface), struct{}{})
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindInterceptor(new(AopInterface), AopInterceptor1{})
+	assert.NoError(t, err)
+
+	binding, err := injector.TryBind(new(AopInterface))
+	require.NoError(t, err)
+	assert.ErrorIs(t, binding.TryTo(new(string)), ErrInvalidBinding)
+	assert.NoError(t, binding.TryTo(AopImpl{}))
+
+	assert.PanicsWithError(t, "invalid binding: cannot bind nil", func() {
+		injector.Bind(nil)
+	})
+}
@@ -0,0 +1,756 @@
+// Package dingogen generates Go source with typed constructors for types wired by a dingo injector.
+//
+// The generated constructors call To types, providers and Inject methods directly instead of resolving them via
+// reflection, bindings the generator can not express (scoped, intercepted and decorated bindings, instances, closures,
+// multi- and map bindings) are resolved by the reflective injector passed to the constructor.
+// Missing bindings and circular dependencies are reported by Generate, instead of when the code runs.
+//
+// Usually the generator runs via the cmd/dingogen command:
+//
+//	//go:generate go run flamingo.me/dingo/cmd/dingogen -o dingo_gen.go -module example.com/app.Module -root NewServer=*example.com/app.Server
+package dingogen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	// Config defines the generated file
+	Config struct {
+		Package     string // package name of the generated file
+		PackagePath string // import path of the generated file's package, its types are referenced without qualifier
+		Roots       []Root
+	}
+
+	// Root is a type a constructor function is generated for
+	Root struct {
+		Name       string       // name of the generated function, e.g. NewServer
+		Type       reflect.Type // requested type, e.g. reflect.TypeFor[*app.Server]()
+		Annotation string
+	}
+
+	// generator collects the generated functions and the imports they need
+	generator struct {
+		config      Config
+		bindings    map[key]binding
+		intercepted map[reflect.Type]bool
+		imports     map[string]string // import path to alias
+		aliases     map[string]bool
+		names       map[string]bool
+		functions   map[key]*function
+		order       []*function
+		fallback    bool
+	}
+
+	key struct {
+		t          reflect.Type
+		annotation string
+	}
+
+	// binding is a binding as reported by dingo.Injector.Inspect
+	binding struct {
+		to       reflect.Type
+		provider *reflect.Value
+		instance *reflect.Value
+		scope    dingo.Scope
+	}
+
+	// function is a generated constructor for a requested type and annotation
+	function struct {
+		name     string
+		key      key
+		body     string
+		building bool
+	}
+
+	// unboundError reports a type the injector can not resolve
+	unboundError struct {
+		key key
+	}
+)
+
+// errFallback signals that a type is resolved by the reflective injector
+var errFallback = errors.New("fallback to injector")
+
+var errorType = reflect.TypeFor[error]()
+
+func (err *unboundError) Error() string {
+	if err.key.annotation != "" {
+		return fmt.Sprintf("no binding for %s annotated with %q", err.key.t, err.key.annotation)
+	}
+	return fmt.Sprintf("no binding for %s", err.key.t)
+}
+
+// Generate creates the source of a Go file with a constructor function for each root.
+// The constructors take the injector the bindings were read from, to resolve what can not be generated.
+func Generate(injector *dingo.Injector, config Config) ([]byte, error) {
+	g := &generator{
+		config:      config,
+		bindings:    make(map[key]binding),
+		intercepted: make(map[reflect.Type]bool),
+		imports:     make(map[string]string),
+		aliases:     make(map[string]bool),
+		names:       make(map[string]bool),
+		functions:   make(map[key]*function),
+	}
+
+	for _, reserved := range []string{"dingo", "fmt", "reflect", "injector", "result", "err", "v"} {
+		g.aliases[reserved] = true
+	}
+	g.imports[reflect.TypeFor[dingo.Injector]().PkgPath()] = "dingo"
+	g.inspect(injector)
+
+	for _, root := range config.Roots {
+		if !token.IsIdentifier(root.Name) {
+			return nil, fmt.Errorf("dingogen: invalid root name %q", root.Name)
+		}
+		if g.names[root.Name] {
+			return nil, fmt.Errorf("dingogen: duplicate root name %q", root.Name)
+		}
+		g.names[root.Name] = true
+	}
+
+	var roots bytes.Buffer
+	for _, root := range config.Roots {
+		typ, err := g.typeExpr(root.Type)
+		if err != nil {
+			return nil, fmt.Errorf("dingogen: %s: %w", root.Name, err)
+		}
+
+		fn, err := g.resolve(key{t: root.Type, annotation: root.Annotation})
+		if err != nil {
+			return nil, fmt.Errorf("dingogen: %s: %w", root.Name, err)
+		}
+
+		_, _ = fmt.Fprintf(&roots, "// %s creates %s", root.Name, typ)
+		if root.Annotation != "" {
+			_, _ = fmt.Fprintf(&roots, " annotated with %q", root.Annotation)
+		}
+		_, _ = fmt.Fprintf(&roots, ".\nfunc %s(injector *dingo.Injector) (%s, error) {\n\treturn %s(injector)\n}\n\n", root.Name, typ, fn.name)
+	}
+
+	var code bytes.Buffer
+	code.Write(roots.Bytes())
+	for _, fn := range g.order {
+		typ, _ := g.typeExpr(fn.key.t)
+		_, _ = fmt.Fprintf(&code, "func %s(injector *dingo.Injector) (result %s, err error) {\n%s}\n\n", fn.name, typ, fn.body)
+	}
+	if g.fallback {
+		g.imports["fmt"] = "fmt"
+		g.imports["reflect"] = "reflect"
+		code.WriteString(fallbackHelper)
+	}
+
+	used, err := usedPackages(code.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("dingogen: parsing generated source: %w", err)
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by dingogen. DO NOT EDIT.\n\n")
+	_, _ = fmt.Fprintf(&src, "package %s\n\n", config.Package)
+	g.writeImports(&src, used)
+	src.Write(code.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("dingogen: formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+const fallbackHelper = `// dingogenResolve resolves the requested type with the reflective injector.
+func dingogenResolve[T any](injector *dingo.Injector, annotation string) (T, error) {
+	var zero T
+	instance, err := injector.GetAnnotatedInstance(reflect.TypeFor[T](), annotation)
+	if err != nil {
+		return zero, err
+	}
+	switch instance := instance.(type) {
+	case T:
+		return instance, nil
+	case *T:
+		return *instance, nil
+	}
+	return zero, fmt.Errorf("dingogen: %T is not assignable to %s", instance, reflect.TypeFor[T]())
+}
+`
+
+// inspect collects the bindings of the injector and its parents, the injector's own bindings take precedence
+func (g *generator) inspect(injector *dingo.Injector) {
+	for level := injector; level != nil; {
+		var parent *dingo.Injector
+		local := make(map[key]binding)
+		level.Inspect(dingo.Inspector{
+			InspectBinding: func(of reflect.Type, annotation string, to reflect.Type, provider, instance *reflect.Value, in dingo.Scope) {
+				k := key{t: of, annotation: annotation}
+				if _, ok := local[k]; !ok {
+					local[k] = binding{to: to, provider: provider, instance: instance, scope: in}
+				}
+			},
+			InspectInterceptor: func(of reflect.Type, _ reflect.Type) {
+				g.intercepted[of] = true
+			},
+			InspectDecorator: func(of reflect.Type, _ reflect.Value) {
+				g.intercepted[of] = true
+			},
+			InspectParent: func(p *dingo.Injector) {
+				parent = p
+			},
+		})
+
+		for k, b := range local {
+			if _, ok := g.bindings[k]; !ok {
+				g.bindings[k] = b
+			}
+		}
+		level = parent
+	}
+}
+
+// resolve returns the generated function creating the requested type
+func (g *generator) resolve(k key) (*function, error) {
+	if fn, ok := g.functions[k]; ok {
+		if fn.building {
+			return nil, fmt.Errorf("circular dependency on %s", describe(k))
+		}
+		return fn, nil
+	}
+
+	if _, err := g.typeExpr(k.t); err != nil {
+		return nil, err
+	}
+
+	fn := &function{key: k, building: true}
+	g.functions[k] = fn
+
+	body, err := g.body(k)
+	if errors.Is(err, errFallback) {
+		body, err = g.fallbackBody(k)
+	}
+	if err != nil {
+		delete(g.functions, k)
+		return nil, err
+	}
+
+	fn.name = g.functionName(k)
+	fn.body = body
+	fn.building = false
+	g.order = append(g.order, fn)
+
+	return fn, nil
+}
+
+// body generates the function body like dingo resolves the type, see Injector.getInstanceOfTypeWithAnnotation
+func (g *generator) body(k key) (string, error) {
+	t := k.t
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if g.intercepted[t] || strings.HasPrefix(k.annotation, "map:") {
+		return "", errFallback
+	}
+
+	if b, ok := g.bindings[key{t: t, annotation: k.annotation}]; ok {
+		switch {
+		case b.scope != nil || b.instance != nil:
+			return "", errFallback
+		case b.provider != nil:
+			return g.providerBody(k, *b.provider)
+		case b.to != nil:
+			if b.to == t {
+				return "", fmt.Errorf("circular binding from %s to itself", t)
+			}
+			return g.toBody(k, b.to)
+		case k.annotation != "":
+			// an untargeted annotated binding is resolved without annotation
+			return g.delegateBody(k, key{t: k.t})
+		}
+	}
+
+	switch {
+	case t.Kind() == reflect.Func && (t.NumOut() == 1 || t.NumOut() == 2) && strings.HasSuffix(t.Name(), "Provider"):
+		return "", errFallback
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Map || t.Kind() == reflect.Func && t.PkgPath() == "iter":
+		// multi bindings and map bindings, also iterated with iter.Seq or iter.Seq2
+		return "", errFallback
+	case k.annotation != "" || t.Kind() == reflect.Interface || t.Kind() == reflect.Func:
+		return "", &unboundError{key: key{t: t, annotation: k.annotation}}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return g.structBody(k, t)
+	}
+
+	return "", errFallback
+}
+
+// fallbackBody resolves the type with the reflective injector
+func (g *generator) fallbackBody(k key) (string, error) {
+	typ, err := g.typeExpr(k.t)
+	if err != nil {
+		return "", err
+	}
+
+	g.fallback = true
+	return fmt.Sprintf("\treturn dingogenResolve[%s](injector, %s)\n", typ, strconv.Quote(k.annotation)), nil
+}
+
+// delegateBody returns the result of another generated function
+func (g *generator) delegateBody(k key, to key) (string, error) {
+	fn, err := g.resolve(to)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("\treturn %s(injector)\n", fn.name), nil
+}
+
+// toBody creates the bound type, and converts it to the requested type
+func (g *generator) toBody(k key, to reflect.Type) (string, error) {
+	request := to
+	if to.Kind() == reflect.Struct {
+		request = reflect.PointerTo(to)
+	}
+
+	conversion, ok := convert("v", request, k.t)
+	if !ok {
+		return "", errFallback
+	}
+	if _, err := g.typeExpr(request); err != nil {
+		return "", errFallback
+	}
+
+	fn, err := g.resolve(key{t: request})
+	if err != nil {
+		return "", fmt.Errorf("%s bound to %s: %w", describe(k), to, err)
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "\tv, err := %s(injector)\n", fn.name)
+	sb.WriteString(returnOnError)
+	_, _ = fmt.Fprintf(&sb, "\treturn %s, nil\n", conversion)
+	return sb.String(), nil
+}
+
+// providerBody calls the provider function with its resolved arguments, and injects into the result
+func (g *generator) providerBody(k key, provider reflect.Value) (string, error) {
+	name, ok := g.funcExpr(provider)
+	if !ok {
+		return "", errFallback
+	}
+
+	ft := provider.Type()
+	canError := ft.NumOut() == 2 && ft.Out(1) == errorType
+	if ft.IsVariadic() || ft.NumOut() != 1 && !canError {
+		return "", errFallback
+	}
+
+	conversion, ok := convert("v", ft.Out(0), k.t)
+	if !ok || !g.canInjectInto(ft.Out(0)) {
+		return "", errFallback
+	}
+
+	var sb strings.Builder
+	args := make([]string, ft.NumIn())
+	for i := range args {
+		fn, err := g.resolve(key{t: ft.In(i)})
+		if err != nil {
+			return "", fmt.Errorf("argument %d of provider %s for %s: %w", i, name, describe(k), err)
+		}
+		args[i] = "arg" + strconv.Itoa(i)
+		_, _ = fmt.Fprintf(&sb, "\t%s, err := %s(injector)\n", args[i], fn.name)
+		sb.WriteString(returnOnError)
+	}
+
+	if canError {
+		_, _ = fmt.Fprintf(&sb, "\tv, err := %s(%s)\n", name, strings.Join(args, ", "))
+		sb.WriteString(returnOnError)
+	} else {
+		_, _ = fmt.Fprintf(&sb, "\tv := %s(%s)\n", name, strings.Join(args, ", "))
+	}
+
+	if err := g.injectInto(&sb, ft.Out(0)); err != nil {
+		return "", fmt.Errorf("result of provider %s: %w", name, err)
+	}
+
+	_, _ = fmt.Fprintf(&sb, "\treturn %s, nil\n", conversion)
+	return sb.String(), nil
+}
+
+// structBody creates a new struct and injects into it
+func (g *generator) structBody(k key, t reflect.Type) (string, error) {
+	typ, err := g.typeExpr(t)
+	if err != nil || !g.canInjectInto(reflect.PointerTo(t)) {
+		return "", errFallback
+	}
+
+	conversion, _ := convert("v", reflect.PointerTo(t), k.t)
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "\tv := new(%s)\n", typ)
+	if err := g.injectInto(&sb, reflect.PointerTo(t)); err != nil {
+		return "", fmt.Errorf("%s: %w", t, err)
+	}
+	_, _ = fmt.Fprintf(&sb, "\treturn %s, nil\n", conversion)
+	return sb.String(), nil
+}
+
+// canInjectInto checks if the generated code can inject into a value of type t, i.e. it can set all inject fields
+func (g *generator) canInjectInto(t reflect.Type) bool {
+	switch {
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct:
+		t = t.Elem()
+	case t.Kind() == reflect.Struct:
+		// dingo can not set the fields of a struct value
+		return len(injectFields(t)) == 0
+	default:
+		return true
+	}
+
+	for _, field := range injectFields(t) {
+		if !field.IsExported() && t.PkgPath() != g.config.PackagePath {
+			return false
+		}
+	}
+	return true
+}
+
+// injectInto calls the Inject method of v and sets its inject fields, see Injector.requestInjection
+func (g *generator) injectInto(sb *strings.Builder, t reflect.Type) error {
+	switch {
+	case t.Kind() == reflect.Interface || t.Kind() == reflect.Slice:
+		// the dynamic type is unknown, let the injector do the work
+		sb.WriteString("\tif err = injector.RequestInjection(v); err != nil {\n\t\treturn result, err\n\t}\n")
+		return nil
+	case t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct:
+		return nil
+	}
+
+	if method, ok := t.MethodByName("Inject"); ok {
+		args := make([]string, method.Type.NumIn()-1)
+		for i := range args {
+			fn, err := g.resolve(key{t: method.Type.In(i + 1)})
+			if err != nil {
+				return fmt.Errorf("Inject argument %d: %w", i, err)
+			}
+			args[i] = "inject" + strconv.Itoa(i)
+			_, _ = fmt.Fprintf(sb, "\t%s, err := %s(injector)\n", args[i], fn.name)
+			sb.WriteString(returnOnError)
+		}
+		_, _ = fmt.Fprintf(sb, "\tv.Inject(%s)\n", strings.Join(args, ", "))
+	}
+
+	for _, field := range injectFields(t.Elem()) {
+		tag := field.Tag.Get("inject")
+		var optional bool
+		for _, option := range strings.Split(tag, ",") {
+			if strings.TrimSpace(option) == "optional" {
+				optional = true
+			}
+		}
+		tag = strings.Split(tag, ",")[0]
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			return fmt.Errorf("can not inject into struct field %s", field.Name)
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Interface:
+			return fmt.Errorf("field %s is pointer to interface", field.Name)
+		}
+
+		fn, err := g.resolve(key{t: field.Type, annotation: tag})
+		var unbound *unboundError
+		if optional && errors.As(err, &unbound) && unbound.key.t == derefType(field.Type) && unbound.key.annotation == tag {
+			_, _ = fmt.Fprintf(sb, "\t// %s is optional and not bound\n", field.Name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		_, _ = fmt.Fprintf(sb, "\tif v.%s, err = %s(injector); err != nil {\n\t\treturn result, err\n\t}\n", field.Name, fn.name)
+	}
+
+	return nil
+}
+
+const returnOnError = "\tif err != nil {\n\t\treturn result, err\n\t}\n"
+
+// injectFields returns the fields with an inject tag
+func injectFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("inject"); ok {
+			fields = append(fields, t.Field(i))
+		}
+	}
+	return fields
+}
+
+// convert returns the expression converting v of type from to the type to
+func convert(v string, from, to reflect.Type) (string, bool) {
+	switch {
+	case from.AssignableTo(to):
+		return v, true
+	case from.Kind() == reflect.Ptr && from.Elem().AssignableTo(to):
+		return "*" + v, true
+	}
+	return "", false
+}
+
+// funcExpr returns the qualified name of a top level function, closures and method values are not supported
+func (g *generator) funcExpr(fnc reflect.Value) (string, bool) {
+	f := runtime.FuncForPC(fnc.Pointer())
+	if f == nil {
+		return "", false
+	}
+
+	fullName := f.Name()
+	base := fullName[strings.LastIndex(fullName, "/")+1:]
+	if strings.ContainsAny(base, "()[]-") || strings.Contains(base, ".func") {
+		return "", false
+	}
+
+	dot := strings.LastIndex(fullName, ".")
+	pkgPath, name := fullName[:dot], fullName[dot+1:]
+	if !token.IsIdentifier(name) || pkgPath == "main" && g.config.PackagePath != "main" {
+		return "", false
+	}
+
+	if pkgPath == g.config.PackagePath {
+		return name, true
+	}
+	if !token.IsExported(name) {
+		return "", false
+	}
+
+	return g.importAlias(pkgPath, packageName(pkgPath)) + "." + name, true
+}
+
+// typeExpr returns the Go expression of the type as written in the generated file
+func (g *generator) typeExpr(t reflect.Type) (string, error) {
+	if t.Name() != "" {
+		switch {
+		case t.PkgPath() == "":
+			return t.Name(), nil
+		case strings.Contains(t.Name(), "["):
+			return "", fmt.Errorf("generic type %s is not supported", t)
+		case t.PkgPath() == g.config.PackagePath:
+			return t.Name(), nil
+		case !token.IsExported(t.Name()):
+			return "", fmt.Errorf("unexported type %s can not be referenced", t)
+		case t.PkgPath() == "main":
+			return "", fmt.Errorf("type %s of package main can not be referenced", t)
+		}
+		return g.importAlias(t.PkgPath(), strings.TrimSuffix(t.String(), "."+t.Name())) + "." + t.Name(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := g.typeExpr(t.Elem())
+		return "*" + elem, err
+
+	case reflect.Slice:
+		elem, err := g.typeExpr(t.Elem())
+		return "[]" + elem, err
+
+	case reflect.Array:
+		elem, err := g.typeExpr(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), err
+
+	case reflect.Map:
+		k, err := g.typeExpr(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeExpr(t.Elem())
+		return "map[" + k + "]" + elem, err
+
+	case reflect.Chan:
+		elem, err := g.typeExpr(t.Elem())
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + elem, err
+		case reflect.SendDir:
+			return "chan<- " + elem, err
+		}
+		return "chan " + elem, err
+
+	case reflect.Func:
+		in := make([]string, t.NumIn())
+		for i := range in {
+			var err error
+			if in[i], err = g.typeExpr(t.In(i)); err != nil {
+				return "", err
+			}
+			if t.IsVariadic() && i == len(in)-1 {
+				in[i] = "..." + strings.TrimPrefix(in[i], "[]")
+			}
+		}
+		out := make([]string, t.NumOut())
+		for i := range out {
+			var err error
+			if out[i], err = g.typeExpr(t.Out(i)); err != nil {
+				return "", err
+			}
+		}
+		expr := "func(" + strings.Join(in, ", ") + ")"
+		switch len(out) {
+		case 0:
+			return expr, nil
+		case 1:
+			return expr + " " + out[0], nil
+		}
+		return expr + " (" + strings.Join(out, ", ") + ")", nil
+
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface{}", nil
+		}
+
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return "struct{}", nil
+		}
+	}
+
+	return "", fmt.Errorf("type %s is not supported", t)
+}
+
+// importAlias registers the import of a package and returns its unique alias
+func (g *generator) importAlias(pkgPath, name string) string {
+	if alias, ok := g.imports[pkgPath]; ok {
+		return alias
+	}
+
+	if !token.IsIdentifier(name) {
+		name = "pkg"
+	}
+
+	alias := name
+	for i := 2; g.aliases[alias] || g.names[alias] || isNumbered(alias, "arg") || isNumbered(alias, "inject"); i++ {
+		alias = name + strconv.Itoa(i)
+	}
+
+	g.imports[pkgPath] = alias
+	g.aliases[alias] = true
+	return alias
+}
+
+// usedPackages returns the package names referenced by the code, imports of discarded code are not needed
+func usedPackages(code []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package generated\n"), code...), parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return used, nil
+}
+
+func (g *generator) writeImports(src *bytes.Buffer, used map[string]bool) {
+	var std, other []string
+	for pkgPath, alias := range g.imports {
+		if !used[alias] {
+			continue
+		}
+		spec := strconv.Quote(pkgPath)
+		if alias != packageName(pkgPath) {
+			spec = alias + " " + spec
+		}
+		if strings.Contains(strings.Split(pkgPath, "/")[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	src.WriteString("import (\n")
+	for _, spec := range std {
+		src.WriteString("\t" + spec + "\n")
+	}
+	if len(std) > 0 && len(other) > 0 {
+		src.WriteString("\n")
+	}
+	for _, spec := range other {
+		src.WriteString("\t" + spec + "\n")
+	}
+	src.WriteString(")\n\n")
+}
+
+// functionName returns a unique function name derived from the type and the annotation
+func (g *generator) functionName(k key) string {
+	typ, _ := g.typeExpr(k.t)
+
+	var sb strings.Builder
+	sb.WriteString("new")
+	for _, word := range strings.FieldsFunc(typ+" "+k.annotation, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if k.t.Kind() != reflect.Ptr && k.t.Kind() == reflect.Struct {
+		sb.WriteString("Value")
+	}
+
+	name := sb.String()
+	for i := 2; g.names[name] || g.aliases[name]; i++ {
+		name = sb.String() + strconv.Itoa(i)
+	}
+
+	g.names[name] = true
+	return name
+}
+
+// packageName guesses the package name from the import path, e.g. gopkg.in/yaml.v3 is imported as yaml
+func packageName(pkgPath string) string {
+	name := pkgPath[strings.LastIndex(pkgPath, "/")+1:]
+	if dot := strings.Index(name, "."); dot > 0 {
+		name = name[:dot]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// isNumbered checks if the name is the prefix followed by a number, like the generated argument variables
+func isNumbered(name, prefix string) bool {
+	_, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+	return strings.HasPrefix(name, prefix) && err == nil
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func describe(k key) string {
+	if k.annotation != "" {
+		return fmt.Sprintf("%s annotated with %q", k.t, k.annotation)
+	}
+	return k.t.String()
+}
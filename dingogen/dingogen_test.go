@@ -0,0 +1,93 @@
+package dingogen
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/dingogen/internal/example"
+	"flamingo.me/dingo/dingogen/internal/example/wire"
+)
+
+type (
+	UnboundIface interface{}
+
+	NeedsUnbound struct {
+		Dependency UnboundIface `inject:""`
+	}
+
+	CircularA struct {
+		B *CircularB `inject:""`
+	}
+
+	CircularB struct {
+		A *CircularA `inject:""`
+	}
+)
+
+func exampleInjector(t *testing.T) *dingo.Injector {
+	t.Helper()
+
+	injector, err := dingo.NewInjectorWithOptions(dingo.WithEagerSingletons(false), dingo.WithModules(new(example.Module)))
+	require.NoError(t, err)
+	return injector
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generated example is up to date", func(t *testing.T) {
+		t.Parallel()
+
+		src, err := Generate(exampleInjector(t), Config{
+			Package:     "wire",
+			PackagePath: "flamingo.me/dingo/dingogen/internal/example/wire",
+			Roots:       []Root{{Name: "NewServer", Type: reflect.TypeFor[*example.Server]()}},
+		})
+		require.NoError(t, err)
+
+		expected, err := os.ReadFile("internal/example/wire/dingo_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(src), "run go generate ./dingogen/...")
+	})
+
+	t.Run("unbound dependency", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Generate(exampleInjector(t), Config{
+			Package: "wire",
+			Roots:   []Root{{Name: "NewNeedsUnbound", Type: reflect.TypeFor[*NeedsUnbound]()}},
+		})
+		assert.ErrorContains(t, err, "NewNeedsUnbound: dingogen.NeedsUnbound: field Dependency: no binding for dingogen.UnboundIface")
+	})
+
+	t.Run("circular dependency", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Generate(exampleInjector(t), Config{
+			Package: "wire",
+			Roots:   []Root{{Name: "NewCircularA", Type: reflect.TypeFor[*CircularA]()}},
+		})
+		assert.ErrorContains(t, err, "circular dependency on *dingogen.CircularA")
+	})
+}
+
+func TestGeneratedConstructor(t *testing.T) {
+	t.Parallel()
+
+	injector := exampleInjector(t)
+
+	server, err := wire.NewServer(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello #1", server.Handle(1))
+	assert.Nil(t, server.Tracer)
+
+	metrics, err := injector.GetInstance(new(example.Metrics))
+	require.NoError(t, err)
+	assert.Same(t, metrics, server.Metrics, "singletons are resolved by the injector")
+}
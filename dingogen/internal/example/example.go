@@ -0,0 +1,97 @@
+// Package example is wired by dingogen, see the generated constructors in package wire.
+package example
+
+import (
+	"fmt"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	// Greeter greets by name
+	Greeter interface {
+		Greet(name string) string
+	}
+
+	// PoliteGreeter greets with the salutation
+	PoliteGreeter struct {
+		Salutation string `inject:"salutation"`
+	}
+
+	// Repository finds names
+	Repository interface {
+		Name(id int) string
+	}
+
+	// MemoryRepository knows a single name
+	MemoryRepository struct {
+		greeter Greeter
+	}
+
+	// Logger logs messages
+	Logger interface {
+		Log(msg string)
+	}
+
+	nopLogger struct{}
+
+	// Tracer is optional and not bound
+	Tracer interface {
+		Trace(msg string)
+	}
+
+	// Metrics are shared as singleton
+	Metrics struct {
+		Requests int
+	}
+
+	// Server uses all of the above
+	Server struct {
+		Greeter    Greeter  `inject:""`
+		Logger     Logger   `inject:""`
+		Tracer     Tracer   `inject:",optional"`
+		Metrics    *Metrics `inject:""`
+		repository Repository
+	}
+
+	// Module binds the example
+	Module struct{}
+)
+
+// Greet the name
+func (g *PoliteGreeter) Greet(name string) string {
+	return fmt.Sprintf("%s %s", g.Salutation, name)
+}
+
+// NewMemoryRepository creates a repository
+func NewMemoryRepository(greeter Greeter) *MemoryRepository {
+	return &MemoryRepository{greeter: greeter}
+}
+
+// Name returns a greeting for every id
+func (r *MemoryRepository) Name(id int) string {
+	return r.greeter.Greet(fmt.Sprintf("#%d", id))
+}
+
+func (nopLogger) Log(string) {}
+
+// Inject dependencies
+func (s *Server) Inject(repository Repository) {
+	s.repository = repository
+}
+
+// Handle a request
+func (s *Server) Handle(id int) string {
+	s.Metrics.Requests++
+	s.Logger.Log("handle")
+	return s.repository.Name(id)
+}
+
+// Configure the bindings
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(Greeter)).To(PoliteGreeter{})
+	injector.Bind(new(string)).AnnotatedWith("salutation").ToInstance("Hello")
+	injector.Bind(new(Repository)).ToProvider(NewMemoryRepository)
+	injector.Bind(new(Logger)).To(nopLogger{})
+	injector.Bind(new(Metrics)).In(dingo.Singleton)
+}
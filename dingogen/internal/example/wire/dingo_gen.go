@@ -0,0 +1,89 @@
+// Code generated by dingogen. DO NOT EDIT.
+
+package wire
+
+import (
+	"fmt"
+	"reflect"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/dingogen/internal/example"
+)
+
+// NewServer creates *example.Server.
+func NewServer(injector *dingo.Injector) (*example.Server, error) {
+	return newExampleServer(injector)
+}
+
+func newStringSalutation(injector *dingo.Injector) (result string, err error) {
+	return dingogenResolve[string](injector, "salutation")
+}
+
+func newExamplePoliteGreeter(injector *dingo.Injector) (result *example.PoliteGreeter, err error) {
+	v := new(example.PoliteGreeter)
+	if v.Salutation, err = newStringSalutation(injector); err != nil {
+		return result, err
+	}
+	return v, nil
+}
+
+func newExampleGreeter(injector *dingo.Injector) (result example.Greeter, err error) {
+	v, err := newExamplePoliteGreeter(injector)
+	if err != nil {
+		return result, err
+	}
+	return v, nil
+}
+
+func newExampleRepository(injector *dingo.Injector) (result example.Repository, err error) {
+	arg0, err := newExampleGreeter(injector)
+	if err != nil {
+		return result, err
+	}
+	v := example.NewMemoryRepository(arg0)
+	return v, nil
+}
+
+func newExampleLogger(injector *dingo.Injector) (result example.Logger, err error) {
+	return dingogenResolve[example.Logger](injector, "")
+}
+
+func newExampleMetrics(injector *dingo.Injector) (result *example.Metrics, err error) {
+	return dingogenResolve[*example.Metrics](injector, "")
+}
+
+func newExampleServer(injector *dingo.Injector) (result *example.Server, err error) {
+	v := new(example.Server)
+	inject0, err := newExampleRepository(injector)
+	if err != nil {
+		return result, err
+	}
+	v.Inject(inject0)
+	if v.Greeter, err = newExampleGreeter(injector); err != nil {
+		return result, err
+	}
+	if v.Logger, err = newExampleLogger(injector); err != nil {
+		return result, err
+	}
+	// Tracer is optional and not bound
+	if v.Metrics, err = newExampleMetrics(injector); err != nil {
+		return result, err
+	}
+	return v, nil
+}
+
+// dingogenResolve resolves the requested type with the reflective injector.
+func dingogenResolve[T any](injector *dingo.Injector, annotation string) (T, error) {
+	var zero T
+	instance, err := injector.GetAnnotatedInstance(reflect.TypeFor[T](), annotation)
+	if err != nil {
+		return zero, err
+	}
+	switch instance := instance.(type) {
+	case T:
+		return instance, nil
+	case *T:
+		return *instance, nil
+	}
+	return zero, fmt.Errorf("dingogen: %T is not assignable to %s", instance, reflect.TypeFor[T]())
+}
@@ -0,0 +1,4 @@
+// Package wire contains the constructors generated for the example module.
+package wire
+
+//go:generate go run flamingo.me/dingo/cmd/dingogen -o dingo_gen.go -module flamingo.me/dingo/dingogen/internal/example.Module -root NewServer=*flamingo.me/dingo/dingogen/internal/example.Server
@@ -0,0 +1,293 @@
+package dingo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+type (
+	// InitReport describes the construction of the eager singletons of an injector
+	InitReport struct {
+		EagerSingletons []EagerSingletonReport
+		Duration        time.Duration
+	}
+
+	// EagerSingletonReport describes the construction of a single eager singleton
+	EagerSingletonReport struct {
+		Type         reflect.Type
+		Annotation   string
+		Duration     time.Duration
+		Dependencies []Dependency // statically determined transitive dependencies
+		Err          error
+	}
+
+	// eagerSingleton is an eager singleton binding with its transitive dependencies
+	eagerSingleton struct {
+		binding      *Binding
+		dependencies []Dependency
+		after        []int // indices of the eager singletons which are dependencies of this one
+	}
+)
+
+// BuildEagerSingletons requests one instance of each singleton, optional letting the parent injector(s) do the same.
+// Eager singletons are built in a deterministic order, dependencies first, and the result is available via InitReport.
+// With WithParallelEagerSingletons independent eager singletons are built concurrently.
+// The construction of async eager singletons is started in the background first, see WaitReady.
+func (injector *Injector) BuildEagerSingletons(includeParent bool) error {
+	injector.startAsyncSingletons()
+
+	report := new(InitReport)
+	start := time.Now()
+
+	var err error
+	if eagers := injector.eagerSingletons(); injector.options.eagerWorkers > 1 {
+		report.EagerSingletons, err = injector.buildEagerSingletonsParallel(eagers, injector.options.eagerWorkers)
+	} else {
+		report.EagerSingletons, err = injector.buildEagerSingletonsSequential(eagers)
+	}
+
+	report.Duration = time.Since(start)
+	injector.initReport = report
+
+	if err != nil {
+		return fmt.Errorf("initmodules: loading eager singletons: %w", err)
+	}
+
+	if includeParent && injector.parent != nil {
+		return injector.parent.BuildEagerSingletons(includeParent)
+	}
+	return nil
+}
+
+// buildEagerSingletonsSequential builds the eager singletons one after another and stops at the first error
+func (injector *Injector) buildEagerSingletonsSequential(eagers []eagerSingleton) ([]EagerSingletonReport, error) {
+	entries := make([]EagerSingletonReport, 0, len(eagers))
+
+	for _, eager := range eagers {
+		entry := injector.buildEagerSingleton(eager)
+		entries = append(entries, entry)
+
+		if entry.Err != nil {
+			return entries, entry.Err
+		}
+	}
+
+	return entries, nil
+}
+
+// buildEagerSingletonsParallel builds eager singletons as soon as their eager dependencies are built, using a bounded
+// number of workers. Eager singletons depending on a failed one are not built, all errors are collected.
+func (injector *Injector) buildEagerSingletonsParallel(eagers []eagerSingleton, workers int) ([]EagerSingletonReport, error) {
+	entries := make([]EagerSingletonReport, len(eagers))
+	pending := make([]int, len(eagers))
+	dependents := make([][]int, len(eagers))
+	skipped := make([]bool, len(eagers))
+
+	for i, eager := range eagers {
+		pending[i] = len(eager.after)
+		for _, j := range eager.after {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		ready = make(chan int, len(eagers))
+	)
+
+	// skip marks all transitive dependents of a failed eager singleton as not built, mu must be held
+	var skip func(i int)
+	skip = func(i int) {
+		for _, d := range dependents[i] {
+			if !skipped[d] {
+				skipped[d] = true
+				entries[d] = injector.newEagerSingletonReport(eagers[d])
+				entries[d].Err = fmt.Errorf("not built, dependency %s failed", Dependency{Type: eagers[i].binding.typeof, Annotation: eagers[i].binding.annotatedWith})
+				skip(d)
+			}
+		}
+	}
+
+	for i := range eagers {
+		if pending[i] == 0 {
+			wg.Add(1)
+			ready <- i
+		}
+	}
+
+	for range min(workers, len(eagers)) {
+		go func() {
+			for i := range ready {
+				entry := injector.buildEagerSingleton(eagers[i])
+
+				mu.Lock()
+				entries[i] = entry
+				if entry.Err != nil {
+					skip(i)
+				} else {
+					for _, d := range dependents[i] {
+						pending[d]--
+						if pending[d] == 0 && !skipped[d] {
+							wg.Add(1)
+							ready <- d
+						}
+					}
+				}
+				mu.Unlock()
+
+				wg.Done()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(ready)
+
+	var errs []error
+	for i, entry := range entries {
+		if entry.Err != nil && !skipped[i] {
+			errs = append(errs, entry.Err)
+		}
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+func (injector *Injector) newEagerSingletonReport(eager eagerSingleton) EagerSingletonReport {
+	return EagerSingletonReport{
+		Type:         eager.binding.typeof,
+		Annotation:   eager.binding.annotatedWith,
+		Dependencies: eager.dependencies,
+	}
+}
+
+func (injector *Injector) buildEagerSingleton(eager eagerSingleton) EagerSingletonReport {
+	entry := injector.newEagerSingletonReport(eager)
+
+	start := time.Now()
+	injector.profile(profileEagerSingleton, eager.binding.typeof, eager.binding.annotatedWith, func() {
+		_, entry.Err = injector.getInstance(eager.binding.typeof, eager.binding.annotatedWith, injector.circularTrace())
+	})
+	entry.Duration = time.Since(start)
+
+	return entry
+}
+
+// WithParallelEagerSingletons builds independent eager singletons concurrently with the given number of workers.
+// The order is derived from the statically determined dependencies of the eager singletons,
+// eager singletons depending on a failed one are not built and all errors are reported together.
+func WithParallelEagerSingletons(workers int) Option {
+	return func(o *options) {
+		o.eagerWorkers = workers
+	}
+}
+
+// InitReport returns the report of the last eager singleton construction, nil if BuildEagerSingletons did not run yet
+func (injector *Injector) InitReport() *InitReport {
+	return injector.initReport
+}
+
+// String renders the report with one line per eager singleton
+func (r *InitReport) String() string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%d eager singleton(s) built in %s\n", len(r.EagerSingletons), r.Duration)
+	for _, entry := range r.EagerSingletons {
+		_, _ = fmt.Fprintf(&sb, "%12s %s", entry.Duration, Dependency{Type: entry.Type, Annotation: entry.Annotation})
+		if len(entry.Dependencies) > 0 {
+			_, _ = fmt.Fprintf(&sb, " (%d dependencies)", len(entry.Dependencies))
+		}
+		if entry.Err != nil {
+			_, _ = fmt.Fprintf(&sb, ": %v", entry.Err)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// eagerSingletons returns the eager singleton bindings ordered by their dependencies, ties are broken by type and annotation
+func (injector *Injector) eagerSingletons() []eagerSingleton {
+	var eagers []eagerSingleton
+	known := make(map[Dependency]bool)
+
+	for _, bindings := range injector.bindings {
+		for _, binding := range bindings {
+			key := Dependency{Type: binding.typeof, Annotation: binding.annotatedWith}
+			if binding.eager && !known[key] {
+				known[key] = true
+				eagers = append(eagers, eagerSingleton{binding: binding})
+			}
+		}
+	}
+
+	slices.SortFunc(eagers, func(a, b eagerSingleton) int {
+		return compareBindings(a.binding, b.binding)
+	})
+
+	index := make(map[Dependency]int64, len(eagers))
+	dependencyGraph := simple.NewDirectedGraph()
+	for i := range eagers {
+		eagers[i].dependencies = injector.transitiveDependencies(eagers[i].binding.typeof, eagers[i].binding.annotatedWith)
+		index[Dependency{Type: eagers[i].binding.typeof, Annotation: eagers[i].binding.annotatedWith}] = int64(i)
+		dependencyGraph.AddNode(simple.Node(i))
+	}
+
+	for i, eager := range eagers {
+		for _, dep := range eager.dependencies {
+			if j, ok := index[dep]; ok && j != int64(i) {
+				dependencyGraph.SetEdge(dependencyGraph.NewEdge(simple.Node(j), simple.Node(i)))
+			}
+		}
+	}
+
+	sorted, err := topo.SortStabilized(dependencyGraph, func(nodes []graph.Node) {
+		slices.SortFunc(nodes, func(a, b graph.Node) int {
+			return int(a.ID() - b.ID())
+		})
+	})
+	if err != nil {
+		// cyclic eager singletons fail during construction, keep the name order and build them one after another
+		for i := range eagers[1:] {
+			eagers[i+1].after = []int{i}
+		}
+		return eagers
+	}
+
+	position := make(map[int64]int, len(sorted))
+	ordered := make([]eagerSingleton, len(sorted))
+	for i, node := range sorted {
+		position[node.ID()] = i
+		ordered[i] = eagers[node.ID()]
+	}
+
+	for i, node := range sorted {
+		to := dependencyGraph.To(node.ID())
+		for to.Next() {
+			ordered[i].after = append(ordered[i].after, position[to.Node().ID()])
+		}
+		slices.Sort(ordered[i].after)
+	}
+
+	return ordered
+}
+
+func compareBindings(a, b *Binding) int {
+	if c := strings.Compare(a.typeof.String(), b.typeof.String()); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.typeof.PkgPath(), b.typeof.PkgPath()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.annotatedWith, b.annotatedWith)
+}
@@ -0,0 +1,230 @@
+package dingo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	eagerA struct {
+		B *eagerB `inject:""`
+	}
+
+	eagerB struct {
+		c eagerCIface
+	}
+
+	eagerCIface interface{}
+
+	eagerC struct{}
+
+	eagerD struct{}
+
+	eagerFailing struct{}
+)
+
+func (b *eagerB) Inject(c eagerCIface) {
+	b.c = c
+}
+
+func TestBuildEagerSingletons(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dependency order", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithModules(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(eagerA)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(eagerB)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(eagerCIface)).To(eagerC{}).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(eagerD)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(string)).AnnotatedWith("eager").ToInstance("eager").AsEagerSingleton().In(ChildSingleton)
+		})))
+		require.NoError(t, err)
+
+		report := injector.InitReport()
+		require.NotNil(t, report)
+		require.Len(t, report.EagerSingletons, 5)
+
+		var order []string
+		for _, entry := range report.EagerSingletons {
+			order = append(order, Dependency{Type: entry.Type, Annotation: entry.Annotation}.String())
+			assert.NoError(t, entry.Err)
+		}
+
+		assert.Equal(t, []string{
+			"dingo.eagerCIface",
+			"dingo.eagerB",
+			"dingo.eagerA",
+			"dingo.eagerD",
+			`string (annotated with "eager")`,
+		}, order)
+
+		var dependencies []string
+		for _, dep := range report.EagerSingletons[2].Dependencies {
+			dependencies = append(dependencies, dep.String())
+		}
+		assert.Equal(t, []string{"dingo.eagerB", "dingo.eagerCIface", "dingo.eagerC"}, dependencies)
+		assert.Contains(t, report.String(), "5 eager singleton(s) built in")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithModules(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(eagerFailing)).ToProvider(func(eagerCIface) *eagerFailing { return nil }).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(eagerD)).AsEagerSingleton().In(ChildSingleton)
+		})))
+		require.Error(t, err)
+
+		report := injector.InitReport()
+		require.Len(t, report.EagerSingletons, 2)
+		assert.NoError(t, report.EagerSingletons[0].Err)
+		assert.True(t, errors.Is(err, report.EagerSingletons[1].Err))
+		assert.Equal(t, typeOf(new(eagerFailing)), report.EagerSingletons[1].Type)
+	})
+}
+
+type (
+	parallelRendezvous struct {
+		mu      sync.Mutex
+		arrived int
+		met     chan struct{}
+	}
+
+	parallelA struct {
+		met bool
+	}
+
+	parallelB struct {
+		met bool
+	}
+
+	parallelC struct {
+		A *parallelA `inject:""`
+		B *parallelB `inject:""`
+	}
+
+	parallelFailing1 struct{}
+	parallelFailing2 struct{}
+
+	parallelDependent struct {
+		F *parallelFailing1 `inject:""`
+	}
+
+	parallelShared struct{}
+
+	parallelSharedUser1 struct {
+		S *parallelShared `inject:""`
+	}
+
+	parallelSharedUser2 struct {
+		S *parallelShared `inject:""`
+	}
+)
+
+var errParallelShared = errors.New("shared dependency failed")
+
+// wait blocks until two goroutines arrived, or a timeout is reached
+func (r *parallelRendezvous) wait() bool {
+	r.mu.Lock()
+	r.arrived++
+	if r.arrived == 2 {
+		close(r.met)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.met:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+func (a *parallelA) Inject(r *parallelRendezvous) {
+	a.met = r.wait()
+}
+
+func (b *parallelB) Inject(r *parallelRendezvous) {
+	b.met = r.wait()
+}
+
+func TestParallelEagerSingletons(t *testing.T) {
+	t.Parallel()
+
+	t.Run("independent singletons are built concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithParallelEagerSingletons(4), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(parallelRendezvous)).ToInstance(&parallelRendezvous{met: make(chan struct{})})
+			injector.Bind(new(parallelA)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(parallelB)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(parallelC)).AsEagerSingleton().In(ChildSingleton)
+		})))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(parallelC))
+		require.NoError(t, err)
+		assert.True(t, i.(*parallelC).A.met)
+		assert.True(t, i.(*parallelC).B.met)
+
+		report := injector.InitReport()
+		require.Len(t, report.EagerSingletons, 3)
+		assert.Equal(t, typeOf(new(parallelC)), report.EagerSingletons[2].Type)
+	})
+
+	t.Run("errors are aggregated", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithParallelEagerSingletons(2), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(parallelFailing1)).ToProvider(func(eagerCIface) *parallelFailing1 { return nil }).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(parallelFailing2)).ToProvider(func(testInterface) *parallelFailing2 { return nil }).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(parallelDependent)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(eagerD)).AsEagerSingleton().In(ChildSingleton)
+		})))
+		require.Error(t, err)
+
+		report := injector.InitReport()
+		require.Len(t, report.EagerSingletons, 4)
+
+		assert.NoError(t, report.EagerSingletons[0].Err, "eagerD")
+		assert.Error(t, report.EagerSingletons[1].Err, "parallelFailing1")
+		assert.ErrorContains(t, report.EagerSingletons[2].Err, "not built, dependency dingo.parallelFailing1 failed")
+		assert.Error(t, report.EagerSingletons[3].Err, "parallelFailing2")
+		assert.ErrorContains(t, err, "can not instantiate interface flamingo.me/dingo.eagerCIface")
+		assert.ErrorContains(t, err, "can not instantiate interface flamingo.me/dingo.testInterface")
+		assert.NotContains(t, err.Error(), "not built")
+	})
+
+	t.Run("failed shared dependencies report their error", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		injector, err := NewInjectorWithOptions(WithParallelEagerSingletons(2), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.BindScope(NewChildSingletonScope())
+			injector.Bind(new(parallelShared)).ToProvider(func() (*parallelShared, error) {
+				calls++
+				return nil, errParallelShared
+			}).In(ChildSingleton)
+			injector.Bind(new(parallelSharedUser1)).AsEagerSingleton().In(ChildSingleton)
+			injector.Bind(new(parallelSharedUser2)).AsEagerSingleton().In(ChildSingleton)
+		})))
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+
+		report := injector.InitReport()
+		require.Len(t, report.EagerSingletons, 2)
+		for _, eager := range report.EagerSingletons {
+			assert.ErrorIs(t, eager.Err, errParallelShared, eager.Type.String())
+		}
+
+		_, err = injector.GetInstance(new(parallelShared))
+		assert.ErrorIs(t, err, errParallelShared, "later requests fail with the same error")
+	})
+}
@@ -0,0 +1,195 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InjectionPointKind describes how a type is requested
+type InjectionPointKind int
+
+const (
+	// InjectionPointField is an inject tagged struct field
+	InjectionPointField InjectionPointKind = iota
+	// InjectionPointInjectArgument is an argument of an Inject method
+	InjectionPointInjectArgument
+	// InjectionPointProviderArgument is an argument of a provider function
+	InjectionPointProviderArgument
+	// InjectionPointDecoratorArgument is an injected argument of a decorator function
+	InjectionPointDecoratorArgument
+)
+
+var errorType = reflect.TypeOf(new(error)).Elem()
+
+type (
+	// InjectionPoint is a step of the path which lead to a resolution error
+	InjectionPoint struct {
+		Kind       InjectionPointKind
+		Owner      reflect.Type // type injected into, or the type bound to the provider
+		Field      string       // name of the field for InjectionPointField
+		Parameter  int          // index of the argument for Inject, provider and decorator arguments
+		Type       reflect.Type // requested type
+		Annotation string
+	}
+
+	// UnboundError is returned if a requested type has no binding and can not be created just in time
+	UnboundError struct {
+		Type            reflect.Type
+		Annotation      string
+		Path            []InjectionPoint
+		Annotations     []string       // annotations the type is bound with instead
+		Implementations []reflect.Type // bound types implementing the requested interface
+		PointerBound    bool           // a pointer to the requested interface is bound
+		message         string
+		binding         *Binding // set if the binding exists, but has no target
+	}
+
+	// AnnotationNotFoundError is returned if there is no binding for a requested annotation
+	AnnotationNotFoundError struct {
+		Type        reflect.Type
+		Annotation  string
+		Path        []InjectionPoint
+		Suggestions []string // bound annotations closest to the requested one
+		Annotations []string // all annotations the type is bound with
+	}
+
+	// ProviderError is returned if a provider failed, or if a provider or Inject method panicked with WithPanicRecovery
+	ProviderError struct {
+		Type       reflect.Type
+		Annotation string
+		Provider   string // name of the provider function
+		Err        error
+		Path       []InjectionPoint
+		Panic      interface{} // recovered value if the provider panicked, see WithPanicRecovery
+		Stack      []byte      // stack trace of the panic
+	}
+
+	// ScopeError is returned if the scope of a binding is unknown, or does not resolve the type
+	ScopeError struct {
+		Type       reflect.Type
+		Annotation string
+		Scope      Scope
+		Path       []InjectionPoint
+		message    string
+	}
+
+	// CycleError is returned, or raised with circular tracing, on circular dependencies
+	CycleError struct {
+		Type       reflect.Type
+		Annotation string
+		Path       []InjectionPoint
+		message    string
+	}
+)
+
+// String describes the injection point in a single line
+func (p InjectionPoint) String() string {
+	var sb strings.Builder
+
+	switch p.Kind {
+	case InjectionPointField:
+		_, _ = fmt.Fprintf(&sb, "field %s of %s: %s", p.Field, p.Owner, p.Type)
+	case InjectionPointInjectArgument:
+		_, _ = fmt.Fprintf(&sb, "Inject argument %d of %s: %s", p.Parameter, p.Owner, p.Type)
+	case InjectionPointProviderArgument:
+		_, _ = fmt.Fprintf(&sb, "argument %d of provider for %s: %s", p.Parameter, p.Owner, p.Type)
+	case InjectionPointDecoratorArgument:
+		_, _ = fmt.Fprintf(&sb, "argument %d of decorator for %s: %s", p.Parameter, p.Owner, p.Type)
+	}
+	if p.Annotation != "" {
+		_, _ = fmt.Fprintf(&sb, " annotated with %q", p.Annotation)
+	}
+
+	return sb.String()
+}
+
+// FormatPath renders the injection path with one indented line per injection point, starting at the outermost request
+func FormatPath(path []InjectionPoint) string {
+	var sb strings.Builder
+	for i, point := range path {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Repeat("  ", i))
+		sb.WriteString(point.String())
+	}
+	return sb.String()
+}
+
+// errorWithPath appends the rendered path to the message
+func errorWithPath(message string, path []InjectionPoint) string {
+	if len(path) == 0 {
+		return message
+	}
+	return message + "\n" + FormatPath(path)
+}
+
+func (err *UnboundError) Error() string {
+	message := err.message
+	if message == "" {
+		message = fmt.Sprintf("no binding for %s", Dependency{Type: err.Type, Annotation: err.Annotation})
+	}
+	return errorWithPath(message+hints(err.Type, nil, err.Annotations, err.Implementations, err.PointerBound), err.Path)
+}
+
+func (err *AnnotationNotFoundError) Error() string {
+	message := fmt.Sprintf("can not automatically create an annotated injection %q with annotation %q", err.Type, err.Annotation)
+	return errorWithPath(message+hints(err.Type, err.Suggestions, err.Annotations, nil, false), err.Path)
+}
+
+func (err *ProviderError) Error() string {
+	if err.Panic != nil {
+		return errorWithPath(fmt.Sprintf("provider %s for %s panicked: %v", err.Provider, Dependency{Type: err.Type, Annotation: err.Annotation}, err.Panic), err.Path)
+	}
+	return errorWithPath(fmt.Sprintf("provider %s for %s failed: %v", err.Provider, Dependency{Type: err.Type, Annotation: err.Annotation}, err.Err), err.Path)
+}
+
+// Unwrap returns the error of the provider
+func (err *ProviderError) Unwrap() error {
+	return err.Err
+}
+
+func (err *ScopeError) Error() string {
+	return errorWithPath(err.message, err.Path)
+}
+
+func (err *CycleError) Error() string {
+	message := err.message
+	if message == "" {
+		message = fmt.Sprintf("circular dependency on %s", Dependency{Type: err.Type, Annotation: err.Annotation})
+	}
+	return errorWithPath(message, err.Path)
+}
+
+// withInjectionPoint returns a copy of a resolution error with the injection point prepended to its path.
+// Errors are copied, because they might be returned more than once, e.g. by async eager singletons.
+func withInjectionPoint(err error, point InjectionPoint) (error, bool) {
+	switch err := err.(type) {
+	case *UnboundError:
+		c := *err
+		c.Path = prependPath(point, err.Path)
+		return &c, true
+	case *AnnotationNotFoundError:
+		c := *err
+		c.Path = prependPath(point, err.Path)
+		return &c, true
+	case *ProviderError:
+		c := *err
+		c.Path = prependPath(point, err.Path)
+		return &c, true
+	case *ScopeError:
+		c := *err
+		c.Path = prependPath(point, err.Path)
+		return &c, true
+	case *CycleError:
+		c := *err
+		c.Path = prependPath(point, err.Path)
+		return &c, true
+	}
+	return err, false
+}
+
+func prependPath(point InjectionPoint, path []InjectionPoint) []InjectionPoint {
+	return append([]InjectionPoint{point}, path...)
+}
@@ -0,0 +1,153 @@
+package dingo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	errorsRoot struct {
+		Mid *errorsMid `inject:""`
+	}
+
+	errorsMid struct {
+		iface errorsIface
+	}
+
+	errorsIface interface{}
+
+	errorsAnnotated struct {
+		Value string `inject:"missing"`
+	}
+
+	errorsProvided struct{}
+
+	errorsScoped struct{}
+
+	errorsScope struct{}
+
+	errorsSelf struct{}
+)
+
+var errProviderFailed = errors.New("provider failed")
+
+func (m *errorsMid) Inject(iface errorsIface) {
+	m.iface = iface
+}
+
+func (errorsScope) ResolveType(t reflect.Type, annotation string, unscoped func(t reflect.Type, annotation string, optional bool) (reflect.Value, error)) (reflect.Value, error) {
+	return unscoped(t, annotation, false)
+}
+
+func TestResolutionErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unbound with path", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsRoot))
+
+		var unbound *UnboundError
+		require.ErrorAs(t, err, &unbound)
+		assert.Equal(t, reflect.TypeOf(new(errorsIface)).Elem(), unbound.Type)
+		assert.Equal(t, []InjectionPoint{
+			{Kind: InjectionPointField, Owner: reflect.TypeOf(errorsRoot{}), Field: "Mid", Type: reflect.TypeOf(new(errorsMid))},
+			{Kind: InjectionPointInjectArgument, Owner: reflect.TypeOf(errorsMid{}), Parameter: 0, Type: reflect.TypeOf(new(errorsIface)).Elem()},
+		}, unbound.Path)
+
+		assert.Equal(t, "can not instantiate interface flamingo.me/dingo.errorsIface\n"+
+			"field Mid of dingo.errorsRoot: *dingo.errorsMid\n"+
+			"  Inject argument 0 of dingo.errorsMid: dingo.errorsIface", err.Error())
+
+		_, err = injector.GetInstance(new(errorsRoot))
+		require.ErrorAs(t, err, &unbound)
+		assert.Len(t, unbound.Path, 2, "errors are not shared between resolutions")
+	})
+
+	t.Run("annotation not found", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsAnnotated))
+
+		var notFound *AnnotationNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "missing", notFound.Annotation)
+		assert.Equal(t, "Value", notFound.Path[0].Field)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(errorsProvided)).ToProvider(func() (*errorsProvided, error) {
+				return nil, errProviderFailed
+			})
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsProvided))
+
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.ErrorIs(t, err, errProviderFailed)
+		assert.Contains(t, providerErr.Provider, "TestResolutionErrors")
+	})
+
+	t.Run("provider argument", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(errorsProvided)).ToProvider(func(errorsIface) *errorsProvided {
+				return new(errorsProvided)
+			})
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsProvided))
+
+		var unbound *UnboundError
+		require.ErrorAs(t, err, &unbound)
+		assert.Equal(t, InjectionPointProviderArgument, unbound.Path[0].Kind)
+		assert.Equal(t, reflect.TypeOf(errorsProvided{}), unbound.Path[0].Owner)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(errorsScoped)).In(new(errorsScope))
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsScoped))
+
+		var scopeErr *ScopeError
+		require.ErrorAs(t, err, &scopeErr)
+		assert.IsType(t, new(errorsScope), scopeErr.Scope)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(errorsSelf)).To(errorsSelf{})
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(errorsSelf))
+
+		var cycle *CycleError
+		require.ErrorAs(t, err, &cycle)
+		assert.Equal(t, reflect.TypeOf(errorsSelf{}), cycle.Type)
+	})
+}
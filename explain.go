@@ -0,0 +1,254 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// explainer collects the textual explanation of a type resolution
+type explainer struct {
+	strings.Builder
+	injector *Injector
+	seen     map[identifier]bool
+}
+
+// Explain describes how the injector resolves the requested type with the given annotation.
+// It follows bindings, scopes, interceptors and the dependencies of created types, without creating any instance.
+func (injector *Injector) Explain(of interface{}, annotatedWith string) string {
+	e := &explainer{
+		injector: injector,
+		seen:     make(map[identifier]bool),
+	}
+	e.explain(typeOf(of), annotatedWith, "", 0)
+
+	return e.String()
+}
+
+func (e *explainer) line(depth int, format string, args ...interface{}) {
+	e.WriteString(strings.Repeat("  ", depth))
+	_, _ = fmt.Fprintf(e, format, args...)
+	e.WriteString("\n")
+}
+
+func (e *explainer) explain(t reflect.Type, annotation string, via string, depth int) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	title := t.String()
+	if annotation != "" {
+		title += fmt.Sprintf(" annotated with %q", annotation)
+	}
+	if via != "" {
+		title = via + ": " + title
+	}
+
+	ident := identifier{t, annotation}
+	if e.seen[ident] {
+		e.line(depth, "%s (see above)", title)
+		return
+	}
+	e.seen[ident] = true
+	e.line(depth, "%s", title)
+	depth++
+
+	if binding, level := e.injector.findBindingLevel(t, annotation); binding != nil {
+		e.explainBinding(t, annotation, binding, level, depth)
+	} else {
+		e.explainUnbound(t, annotation, depth)
+	}
+
+	for _, interceptor := range e.injector.InterceptorChain(t, annotation) {
+		level := 0
+		for current := e.injector; current != interceptor.injector; current = current.parent {
+			level++
+		}
+		if interceptor.priority != 0 {
+			e.line(depth, "intercepted by %s (%s, priority %d)", interceptor.interceptor, levelName(level), interceptor.priority)
+		} else {
+			e.line(depth, "intercepted by %s (%s)", interceptor.interceptor, levelName(level))
+		}
+	}
+
+	for level, current := 0, e.injector; current != nil; level, current = level+1, current.parent {
+		for _, decorator := range current.decorators[t] {
+			e.line(depth, "decorated by %s (%s)", funcName(decorator.fnc), levelName(level))
+		}
+	}
+}
+
+func (e *explainer) explainBinding(t reflect.Type, annotation string, binding *Binding, level int, depth int) {
+	e.line(depth, "bound in %s", levelName(level))
+
+	if binding.scope != nil {
+		if binding.eager {
+			e.line(depth, "scope %T (eager)", binding.scope)
+		} else if binding.async {
+			e.line(depth, "scope %T (async eager)", binding.scope)
+		} else {
+			e.line(depth, "scope %T", binding.scope)
+		}
+	}
+
+	switch {
+	case binding.instance != nil:
+		e.line(depth, "to instance of %s", binding.instance.itype)
+
+	case binding.provider != nil:
+		e.line(depth, "to provider %s", funcName(binding.provider.fnc))
+		for i := 0; i < binding.provider.fnc.Type().NumIn(); i++ {
+			e.explain(binding.provider.fnc.Type().In(i), "", fmt.Sprintf("argument %d", i), depth+1)
+		}
+
+	case binding.to != nil:
+		if binding.to == t {
+			e.line(depth, "to %s (circular)", binding.to)
+			return
+		}
+		e.explain(binding.to, "", "to", depth)
+
+	case annotation != "":
+		e.line(depth, "untargeted binding, resolved without annotation")
+		e.explain(t, "", "", depth)
+
+	default:
+		e.line(depth, "untargeted binding, created just in time")
+		e.explainDependencies(t, depth)
+	}
+}
+
+func (e *explainer) explainUnbound(t reflect.Type, annotation string, depth int) {
+	seq, isSeq := iterSeq(t)
+
+	switch {
+	case t.Kind() == reflect.Func && (t.NumOut() == 1 || t.NumOut() == 2) && strings.HasSuffix(t.Name(), "Provider"):
+		e.line(depth, "automatic provider")
+		e.explain(t.Out(0), annotation, "provides", depth)
+
+	case t.Kind() == reflect.Slice:
+		e.explainMultibindings(elemType(t.Elem()), annotation, "multibinding", depth)
+
+	case t.Kind() == reflect.Map:
+		e.explainMapbindings(elemType(t.Elem()), t.Key(), annotation, "map binding", depth)
+
+	case isSeq && seq.key == nil:
+		e.explainMultibindings(derefType(seq.elem), annotation, "lazy multibinding", depth)
+
+	case isSeq:
+		e.explainMapbindings(derefType(seq.elem), seq.key, annotation, "lazy map binding", depth)
+
+	case annotation != "":
+		e.line(depth, "unresolvable: no binding for annotation %q", annotation)
+
+	case t.Kind() == reflect.Interface:
+		e.line(depth, "unresolvable: interface is not bound")
+
+	case t.Kind() == reflect.Func:
+		e.line(depth, "unresolvable: function type without Provider suffix")
+
+	default:
+		e.line(depth, "not bound, created just in time")
+		e.explainDependencies(t, depth)
+	}
+}
+
+func (e *explainer) explainMultibindings(t reflect.Type, annotation string, kind string, depth int) {
+	bindings := e.injector.joinMultibindings(t, annotation)
+	e.line(depth, "%s with %d element(s)", kind, len(bindings))
+	for i, binding := range bindings {
+		e.line(depth+1, "%d: %s", i, describeBinding(binding))
+	}
+}
+
+func (e *explainer) explainMapbindings(t reflect.Type, key reflect.Type, annotation string, kind string, depth int) {
+	bindings := e.injector.joinMapbindings(t, key, annotation)
+	e.line(depth, "%s with %d element(s)", kind, len(bindings))
+	for _, key := range sortedMapKeys(bindings) {
+		e.line(depth+1, "%s: %s", formatMapKey(key), describeBinding(bindings[key]))
+	}
+}
+
+// explainDependencies lists the Inject method arguments and the inject-tagged fields of a struct
+func (e *explainer) explainDependencies(t reflect.Type, depth int) {
+	if method, ok := reflect.PtrTo(t).MethodByName("Inject"); ok {
+		for i := 1; i < method.Type.NumIn(); i++ {
+			e.explain(method.Type.In(i), "", fmt.Sprintf("Inject argument %d", i-1), depth)
+		}
+	}
+
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("inject"); ok {
+			e.explain(t.Field(i).Type, strings.Split(tag, ",")[0], "field "+t.Field(i).Name, depth)
+		}
+	}
+}
+
+// findBindingLevel finds the binding like findBindingForAnnotatedType, and reports which injector in the chain holds it
+func (injector *Injector) findBindingLevel(t reflect.Type, annotation string) (*Binding, int) {
+	for level, current := 0, injector; current != nil; level, current = level+1, current.parent {
+		for _, binding := range current.bindings[t] {
+			if binding.annotatedWith == annotation {
+				return binding, level
+			}
+		}
+
+		if len(annotation) > 4 && annotation[:4] == "map:" {
+			if binding := current.mapbindings[t][annotation[4:]]; binding != nil {
+				return binding, level
+			}
+			return nil, -1
+		}
+	}
+
+	return nil, -1
+}
+
+func levelName(level int) string {
+	if level == 0 {
+		return "this injector"
+	}
+	return fmt.Sprintf("parent injector %d", level)
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Func && strings.HasSuffix(t.Name(), "Provider") {
+		t = t.Out(0)
+	}
+	return t
+}
+
+// describeBinding returns a short description of the binding's target
+func describeBinding(binding *Binding) string {
+	var target string
+	switch {
+	case binding.instance != nil:
+		target = fmt.Sprintf("instance of %s", binding.instance.itype)
+	case binding.provider != nil:
+		target = fmt.Sprintf("provider %s", funcName(binding.provider.fnc))
+	case binding.to != nil:
+		target = fmt.Sprintf("to %s", binding.to)
+	default:
+		target = fmt.Sprintf("untargeted %s", binding.typeof)
+	}
+	if binding.scope != nil {
+		target += fmt.Sprintf(" in %T", binding.scope)
+	}
+	return target
+}
+
+// funcName returns the fully qualified name of a function value
+func funcName(fnc reflect.Value) string {
+	if f := runtime.FuncForPC(fnc.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fnc.Type().String()
+}
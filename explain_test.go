@@ -0,0 +1,52 @@
+package dingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	explainIface interface{}
+
+	explainImpl struct {
+		Name string       `inject:"name"`
+		Dep  *explainDep  `inject:""`
+		Ptr  explainIface `inject:"missing,optional"`
+	}
+
+	explainDep struct {
+		value string
+	}
+)
+
+func (d *explainDep) Inject(value string) {
+	d.value = value
+}
+
+func TestInjector_Explain(t *testing.T) {
+	t.Parallel()
+
+	injector, err := NewInjector()
+	require.NoError(t, err)
+
+	injector.Bind(new(explainIface)).To(explainImpl{}).In(Singleton)
+	injector.Bind(new(string)).AnnotatedWith("name").ToInstance("name")
+
+	child, err := injector.Child()
+	require.NoError(t, err)
+
+	explanation := child.Explain(new(explainIface), "")
+
+	assert.Contains(t, explanation, "dingo.explainIface\n")
+	assert.Contains(t, explanation, "bound in parent injector 1")
+	assert.Contains(t, explanation, "scope *dingo.SingletonScope")
+	assert.Contains(t, explanation, "to: dingo.explainImpl")
+	assert.Contains(t, explanation, "field Name: string annotated with \"name\"")
+	assert.Contains(t, explanation, "to instance of string")
+	assert.Contains(t, explanation, "field Dep: dingo.explainDep")
+	assert.Contains(t, explanation, "Inject argument 0: string")
+	assert.Contains(t, explanation, "field Ptr: dingo.explainIface annotated with \"missing\"")
+	assert.Contains(t, explanation, "unresolvable: no binding for annotation \"missing\"")
+}
@@ -6,8 +6,13 @@ import "reflect"
 type Inspector struct {
 	InspectBinding      func(of reflect.Type, annotation string, to reflect.Type, provider, instance *reflect.Value, in Scope)
 	InspectMultiBinding func(of reflect.Type, index int, annotation string, to reflect.Type, provider, instance *reflect.Value, in Scope)
-	InspectMapBinding   func(of reflect.Type, key string, annotation string, to reflect.Type, provider, instance *reflect.Value, in Scope)
+	InspectMapBinding   func(of reflect.Type, key string, annotation string, to reflect.Type, provider, instance *reflect.Value, in Scope) // non-string keys are formatted with fmt
 	InspectParent       func(parent *Injector)
+	InspectModule       func(module Module)
+	InspectInterceptor  func(of reflect.Type, interceptor reflect.Type)
+	InspectDecorator    func(of reflect.Type, decorator reflect.Value)
+	InspectScope        func(scope Scope)
+	InspectSingleton    func(of reflect.Type, annotation string, instance reflect.Value, in Scope) // singletons of the injector's bindings
 }
 
 // Inspect the injector
@@ -55,12 +60,103 @@ func (injector *Injector) Inspect(inspector Inspector) {
 				if binding.instance != nil {
 					ival = &binding.instance.ivalue
 				}
-				inspector.InspectMapBinding(t, key, binding.annotatedWith, binding.to, pfnc, ival, binding.scope)
+				name, ok := key.(string)
+				if !ok {
+					name = formatMapKey(key)
+				}
+				inspector.InspectMapBinding(t, name, binding.annotatedWith, binding.to, pfnc, ival, binding.scope)
 			}
 		}
 	}
 
+	if inspector.InspectModule != nil {
+		for _, module := range injector.modules {
+			inspector.InspectModule(module)
+		}
+	}
+
+	if inspector.InspectInterceptor != nil {
+		for t, interceptors := range injector.interceptor {
+			for _, interceptor := range interceptors {
+				inspector.InspectInterceptor(t, interceptor.interceptor)
+			}
+		}
+	}
+
+	if inspector.InspectDecorator != nil {
+		for t, decorators := range injector.decorators {
+			for _, decorator := range decorators {
+				inspector.InspectDecorator(t, decorator.fnc)
+			}
+		}
+	}
+
+	if inspector.InspectScope != nil {
+		for _, scope := range injector.scopes {
+			inspector.InspectScope(scope)
+		}
+	}
+
+	if inspector.InspectSingleton != nil {
+		for _, scope := range injector.scopes {
+			var singletons *SingletonScope
+			switch scope := scope.(type) {
+			case *SingletonScope:
+				singletons = scope
+			case *ChildSingletonScope:
+				singletons = (*SingletonScope)(scope)
+			default:
+				continue
+			}
+			// the Singleton scope is shared by all injectors of the process, a child singleton scope is shared with the
+			// parent only for the root injectors
+			chain := injector.parent != nil && injector.parent.scopes[reflect.TypeOf(scope)] != scope
+			singletons.instances.Range(func(key, value any) bool {
+				ident := key.(identifier)
+				if instance := value.(reflect.Value); instance.IsValid() && injector.scopedBinding(ident, scope, chain) {
+					inspector.InspectSingleton(ident.t, ident.a, instance, scope)
+				}
+				return true
+			})
+		}
+	}
+
 	if inspector.InspectParent != nil && injector.parent != nil {
 		inspector.InspectParent(injector.parent)
 	}
 }
+
+// scopedBinding checks if a singleton was created for a binding of the injector, with chain also of its parents
+func (injector *Injector) scopedBinding(ident identifier, scope Scope, chain bool) bool {
+	inScope := func(binding *Binding) bool {
+		return binding.scope != nil && reflect.TypeOf(binding.scope) == reflect.TypeOf(scope)
+	}
+
+	for current := injector; current != nil; current = current.parent {
+		for _, binding := range current.bindings[ident.t] {
+			if binding.annotatedWith == ident.a && inScope(binding) {
+				return true
+			}
+		}
+		if ident.a == "" {
+			for _, bindings := range current.multibindings {
+				for _, binding := range bindings {
+					if binding.to == ident.t && inScope(binding) {
+						return true
+					}
+				}
+			}
+			for _, bindings := range current.mapbindings {
+				for _, binding := range bindings {
+					if binding.to == ident.t && inScope(binding) {
+						return true
+					}
+				}
+			}
+		}
+		if !chain {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+// Package intercept provides method level interception of interfaces.
+//
+// Proxies generated by cmd/dingointercept are bound as dingo interceptors of an interface, and call the
+// MethodInterceptors bound for the interface and the annotation of the intercepted binding on every method call:
+//
+//	//go:generate go run flamingo.me/dingo/cmd/dingointercept -type Service
+//
+//	injector.BindInterceptor(new(Service), ServiceProxy{})
+//	intercept.BindMethodInterceptor(injector, new(Service)).To(LoggingInterceptor{})
+//	intercept.BindAnnotatedMethodInterceptor(injector, new(Service), "audit").To(AuditInterceptor{})
+package intercept
+
+import (
+	"context"
+	"reflect"
+
+	"flamingo.me/dingo"
+)
+
+type (
+	// MethodInterceptor intercepts the method calls of an interface
+	MethodInterceptor interface {
+		// Invoke is called instead of the method, it calls ctx.Proceed() to continue with the call
+		Invoke(ctx Invocation) []reflect.Value
+	}
+
+	// MethodInterceptorFunc is a function used as MethodInterceptor
+	MethodInterceptorFunc func(ctx Invocation) []reflect.Value
+
+	// Invocation is a single intercepted method call
+	Invocation struct {
+		Interface reflect.Type    // intercepted interface
+		Method    string          // name of the called method
+		Arguments []reflect.Value // arguments, variadic arguments are passed as slice
+		Element   *dingo.Element  // the multi binding or map binding element, nil for other injections
+		target    reflect.Value
+		next      []MethodInterceptor
+	}
+)
+
+var contextType = reflect.TypeOf(new(context.Context)).Elem()
+
+// Invoke calls the function
+func (f MethodInterceptorFunc) Invoke(ctx Invocation) []reflect.Value {
+	return f(ctx)
+}
+
+// Proceed calls the next interceptor, or the method of the intercepted instance, with the invocation's arguments
+func (ctx Invocation) Proceed() []reflect.Value {
+	if len(ctx.next) > 0 {
+		next := ctx
+		next.next = ctx.next[1:]
+		return ctx.next[0].Invoke(next)
+	}
+
+	method := ctx.target.MethodByName(ctx.Method)
+	if method.Type().IsVariadic() {
+		return method.CallSlice(ctx.Arguments)
+	}
+	return method.Call(ctx.Arguments)
+}
+
+// Context returns the first argument if it is a context.Context, context.Background() otherwise
+func (ctx Invocation) Context() context.Context {
+	if len(ctx.Arguments) > 0 && ctx.Arguments[0].Type() == contextType && !ctx.Arguments[0].IsNil() {
+		return ctx.Arguments[0].Interface().(context.Context)
+	}
+	return context.Background()
+}
+
+// Annotation returns the annotation of the MethodInterceptor multi binding of the bindings of an interface
+// without annotation
+func Annotation(iface interface{}) string {
+	return AnnotationFor(iface, "")
+}
+
+// AnnotationFor returns the annotation of the MethodInterceptor multi binding of the bindings of an interface
+// with the annotation
+func AnnotationFor(iface interface{}, annotation string) string {
+	t := reflect.TypeOf(iface)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if annotation == "" {
+		return "intercept:" + t.PkgPath() + "." + t.Name()
+	}
+	return "intercept:" + t.PkgPath() + "." + t.Name() + "@" + annotation
+}
+
+// BindMethodInterceptor adds a MethodInterceptor for all methods of the bindings of an interface without annotation,
+// including the elements of multi bindings and map bindings without annotation. The interceptors are called in
+// the order of their bindings, the generated proxy of the interface must be bound with injector.BindInterceptor.
+func BindMethodInterceptor(injector *dingo.Injector, iface interface{}) *dingo.Binding {
+	return BindAnnotatedMethodInterceptor(injector, iface, "")
+}
+
+// BindAnnotatedMethodInterceptor adds a MethodInterceptor for all methods of the bindings of an interface with the
+// annotation, see BindMethodInterceptor
+func BindAnnotatedMethodInterceptor(injector *dingo.Injector, iface interface{}, annotation string) *dingo.Binding {
+	return injector.BindMulti(new(MethodInterceptor)).AnnotatedWith(AnnotationFor(iface, annotation))
+}
+
+// Interceptors is used by generated proxies to resolve the MethodInterceptors of the bindings of an interface
+// with the annotation
+func Interceptors(injector *dingo.Injector, iface interface{}, annotation string) ([]MethodInterceptor, error) {
+	interceptors, err := injector.GetAnnotatedInstance(new([]MethodInterceptor), AnnotationFor(iface, annotation))
+	if err != nil {
+		return nil, err
+	}
+	return interceptors.([]MethodInterceptor), nil
+}
+
+// Call is used by generated proxies to call a method of target through the interceptors
+func Call(iface interface{}, target interface{}, element *dingo.Element, method string, interceptors []MethodInterceptor, args ...reflect.Value) []reflect.Value {
+	t := reflect.TypeOf(iface)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return Invocation{
+		Interface: t,
+		Method:    method,
+		Arguments: args,
+		Element:   element,
+		target:    reflect.ValueOf(target),
+		next:      interceptors,
+	}.Proceed()
+}
+
+// Result converts a result of Call, a missing or nil result is returned as zero value
+func Result[T any](results []reflect.Value, i int) T {
+	var result T
+	if i < len(results) && results[i].IsValid() {
+		result, _ = results[i].Interface().(T)
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package intercept_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/intercept"
+	"flamingo.me/dingo/intercept/internal/example"
+)
+
+type (
+	recorder struct {
+		calls []string
+	}
+
+	upper struct{}
+
+	contextKey struct{}
+)
+
+func (r *recorder) Invoke(ctx intercept.Invocation) []reflect.Value {
+	r.calls = append(r.calls, ctx.Interface.Name()+"."+ctx.Method)
+	return ctx.Proceed()
+}
+
+func (upper) Invoke(ctx intercept.Invocation) []reflect.Value {
+	results := ctx.Proceed()
+	if len(results) == 0 {
+		return results
+	}
+	if s, ok := results[0].Interface().(string); ok {
+		results[0] = reflect.ValueOf(strings.ToUpper(s))
+	}
+	return results
+}
+
+func TestMethodInterceptor(t *testing.T) {
+	t.Parallel()
+
+	rec := new(recorder)
+	var ctxValue interface{}
+
+	injector, err := dingo.NewInjector(dingo.ModuleFunc(func(injector *dingo.Injector) {
+		injector.Bind(new(example.Greeter)).To(example.Hello{})
+		injector.BindInterceptor(new(example.Greeter), example.GreeterProxy{})
+		intercept.BindMethodInterceptor(injector, new(example.Greeter)).ToInstance(rec)
+		intercept.BindMethodInterceptor(injector, new(example.Greeter)).ToInstance(intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			ctxValue = ctx.Context().Value(contextKey{})
+			return ctx.Proceed()
+		}))
+		intercept.BindMethodInterceptor(injector, new(example.Greeter)).To(upper{})
+	}))
+	require.NoError(t, err)
+
+	i, err := injector.GetInstance(new(example.Greeter))
+	require.NoError(t, err)
+	greeter := i.(example.Greeter)
+
+	greeting, err := greeter.Greet(context.WithValue(context.Background(), contextKey{}, "value"), "dingo")
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO DINGO", greeting)
+	assert.Equal(t, "value", ctxValue)
+
+	assert.Equal(t, "A, B", greeter.Join(", ", "a", "b"))
+
+	var buf bytes.Buffer
+	greeter.Write(&buf)
+	assert.Equal(t, "hello", buf.String())
+
+	assert.Equal(t, []string{"Greeter.Greet", "Greeter.Join", "Greeter.Write"}, rec.calls)
+}
+
+func TestMethodInterceptorElements(t *testing.T) {
+	t.Parallel()
+
+	var elements []*dingo.Element
+	injector, err := dingo.NewInjector(dingo.ModuleFunc(func(injector *dingo.Injector) {
+		injector.Bind(new(example.Greeter)).To(example.Hello{})
+		injector.BindMulti(new(example.Greeter)).To(example.Hello{})
+		injector.BindInterceptor(new(example.Greeter), example.GreeterProxy{})
+		intercept.BindMethodInterceptor(injector, new(example.Greeter)).ToInstance(intercept.MethodInterceptorFunc(func(ctx intercept.Invocation) []reflect.Value {
+			elements = append(elements, ctx.Element)
+			return ctx.Proceed()
+		}))
+	}))
+	require.NoError(t, err)
+
+	var dep struct {
+		Greeter  example.Greeter   `inject:""`
+		Greeters []example.Greeter `inject:""`
+	}
+	require.NoError(t, injector.RequestInjection(&dep))
+
+	dep.Greeter.Write(new(bytes.Buffer))
+	dep.Greeters[0].Write(new(bytes.Buffer))
+	assert.Equal(t, []*dingo.Element{nil, {Index: 0}}, elements)
+}
+
+func TestAnnotatedMethodInterceptor(t *testing.T) {
+	t.Parallel()
+
+	injector, err := dingo.NewInjector(dingo.ModuleFunc(func(injector *dingo.Injector) {
+		injector.Bind(new(example.Greeter)).To(example.Hello{})
+		injector.Bind(new(example.Greeter)).AnnotatedWith("loud").To(example.Hello{})
+		injector.Bind(new(example.Greeter)).AnnotatedWith("plain").To(example.Hello{})
+		injector.BindInterceptor(new(example.Greeter), example.GreeterProxy{})
+		intercept.BindAnnotatedMethodInterceptor(injector, new(example.Greeter), "loud").To(upper{})
+	}))
+	require.NoError(t, err)
+
+	for annotation, expected := range map[string]string{"": "hello, dingo", "loud": "HELLO, DINGO", "plain": "hello, dingo"} {
+		i, err := injector.GetAnnotatedInstance(new(example.Greeter), annotation)
+		require.NoError(t, err)
+		assert.Equal(t, expected, i.(example.Greeter).Join(", ", "hello", "dingo"), annotation)
+	}
+}
+
+func TestAnnotation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "intercept:flamingo.me/dingo/intercept/internal/example.Greeter", intercept.Annotation(new(example.Greeter)))
+	assert.Equal(t, "intercept:flamingo.me/dingo/intercept/internal/example.Greeter@loud", intercept.AnnotationFor(new(example.Greeter), "loud"))
+}
+
+func TestResult(t *testing.T) {
+	t.Parallel()
+
+	results := []reflect.Value{reflect.ValueOf("a"), reflect.Zero(reflect.TypeOf(new(error)).Elem())}
+	assert.Equal(t, "a", intercept.Result[string](results, 0))
+	assert.NoError(t, intercept.Result[error](results, 1))
+	assert.Equal(t, 0, intercept.Result[int](results, 2))
+}
@@ -0,0 +1,289 @@
+// Package interceptgen generates proxies for interfaces, which call the intercept.MethodInterceptors bound for the
+// interface on every method call.
+//
+// Usually the generator runs via the cmd/dingointercept command:
+//
+//	//go:generate go run flamingo.me/dingo/cmd/dingointercept -type Service
+package interceptgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Config defines the generated file
+type Config struct {
+	Dir   string   // directory of the package declaring the interfaces, the proxies are generated into the same package
+	Tags  []string // build tags used to load the package
+	Types []string // names of the interfaces
+	// Output is the name of the generated file in Dir, a previously generated version is ignored when loading the package
+	Output string
+}
+
+const (
+	dingoPath     = "flamingo.me/dingo"
+	interceptPath = "flamingo.me/dingo/intercept"
+)
+
+// generator collects the generated proxies and the imports they need
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string // import path to alias
+	names   map[string]string // import path to package name
+	aliases map[string]bool
+	code    bytes.Buffer
+}
+
+// Generate generates proxies named <Interface>Proxy for the interfaces
+func Generate(config Config) ([]byte, error) {
+	if len(config.Types) == 0 {
+		return nil, errors.New("interceptgen: no types given")
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax,
+		Dir:  config.Dir,
+	}
+	if len(config.Tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(config.Tags, ",")}
+	}
+	if config.Output != "" {
+		overlay, err := emptyOverlay(filepath.Join(config.Dir, config.Output))
+		if err != nil {
+			return nil, fmt.Errorf("interceptgen: %w", err)
+		}
+		cfg.Overlay = overlay
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, fmt.Errorf("interceptgen: loading package: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("interceptgen: expected one package in %s, found %d", config.Dir, len(pkgs))
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("interceptgen: loading package: %v", pkgs[0].Errors[0])
+	}
+
+	g := &generator{
+		pkg:     pkgs[0].Types,
+		imports: map[string]string{"reflect": "reflect", dingoPath: "dingo", interceptPath: "intercept"},
+		names:   map[string]string{"reflect": "reflect", dingoPath: "dingo", interceptPath: "intercept"},
+		aliases: map[string]bool{"reflect": true, "dingo": true, "intercept": true},
+	}
+
+	for _, name := range config.Types {
+		if err := g.proxy(name); err != nil {
+			return nil, fmt.Errorf("interceptgen: %s: %w", name, err)
+		}
+	}
+
+	used, err := usedPackages(g.code.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("interceptgen: parsing generated code: %w", err)
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by dingointercept. DO NOT EDIT.\n\n")
+	_, _ = fmt.Fprintf(&src, "package %s\n\n", g.pkg.Name())
+	g.writeImports(&src, used)
+	src.Write(g.code.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("interceptgen: formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+// emptyOverlay replaces an existing generated file with its package clause, so outdated proxies do not break loading
+func emptyOverlay(path string) (map[string][]byte, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{path: []byte("package " + file.Name.Name + "\n")}, nil
+}
+
+// proxy generates the proxy type and its methods for the named interface
+func (g *generator) proxy(name string) error {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return errors.New("type not found")
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || !types.IsInterface(named) {
+		return errors.New("not an interface")
+	}
+	if named.TypeParams().Len() > 0 {
+		return errors.New("generic interfaces are not supported")
+	}
+
+	iface := named.Underlying().(*types.Interface)
+	if !iface.IsMethodSet() {
+		return errors.New("constraint interfaces are not supported")
+	}
+	for method := range iface.Methods() {
+		if !method.Exported() {
+			return fmt.Errorf("unexported method %s can not be called by reflection", method.Name())
+		}
+		switch method.Name() {
+		case name, "Injector", "Interceptors", "InterceptAnnotation", "InterceptElement":
+			return fmt.Errorf("method %s conflicts with a field of the proxy", method.Name())
+		}
+	}
+
+	proxy := name + "Proxy"
+	_, _ = fmt.Fprintf(&g.code, "// %s intercepts all methods of %s with the MethodInterceptors bound by intercept.BindMethodInterceptor,\n", proxy, name)
+	_, _ = fmt.Fprintf(&g.code, "// or by intercept.BindAnnotatedMethodInterceptor for annotated bindings\n")
+	_, _ = fmt.Fprintf(&g.code, "type %s struct {\n\t%s\n\tInjector *dingo.Injector `inject:\"\"`\n\tInterceptors []intercept.MethodInterceptor\n\telement *dingo.Element\n}\n", proxy, name)
+	_, _ = fmt.Fprintf(&g.code, "\n// InterceptAnnotation resolves the MethodInterceptors for the annotation of the binding the proxy wraps\n")
+	_, _ = fmt.Fprintf(&g.code, "func (p *%s) InterceptAnnotation(annotation string) (err error) {\n\tp.Interceptors, err = intercept.Interceptors(p.Injector, new(%s), annotation)\n\treturn err\n}\n", proxy, name)
+	_, _ = fmt.Fprintf(&g.code, "\n// InterceptElement stores the multi binding or map binding element the proxy wraps\n")
+	_, _ = fmt.Fprintf(&g.code, "func (p *%s) InterceptElement(element dingo.Element) {\n\tp.element = &element\n}\n", proxy)
+
+	for method := range iface.Methods() {
+		g.method(name, proxy, method)
+	}
+
+	return nil
+}
+
+// method generates a proxy method calling the interceptors
+func (g *generator) method(iface, proxy string, method *types.Func) {
+	sig := method.Signature()
+
+	params := make([]string, sig.Params().Len())
+	args := make([]string, sig.Params().Len())
+	for i := range sig.Params().Len() {
+		typ := sig.Params().At(i).Type()
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			params[i] = fmt.Sprintf("a%d ...%s", i, g.typeString(typ.(*types.Slice).Elem()))
+		} else {
+			params[i] = fmt.Sprintf("a%d %s", i, g.typeString(typ))
+		}
+		args[i] = fmt.Sprintf(", reflect.ValueOf(&a%d).Elem()", i)
+	}
+
+	results := make([]string, sig.Results().Len())
+	returns := make([]string, sig.Results().Len())
+	for i := range sig.Results().Len() {
+		results[i] = g.typeString(sig.Results().At(i).Type())
+		returns[i] = fmt.Sprintf("intercept.Result[%s](r, %d)", results[i], i)
+	}
+
+	resultList := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultList = "(" + resultList + ")"
+	}
+
+	_, _ = fmt.Fprintf(&g.code, "\n// %s calls %s.%s through the interceptors\n", method.Name(), iface, method.Name())
+	_, _ = fmt.Fprintf(&g.code, "func (p *%s) %s(%s) %s {\n", proxy, method.Name(), strings.Join(params, ", "), resultList)
+	call := fmt.Sprintf("intercept.Call(new(%s), p.%s, p.element, %q, p.Interceptors%s)", iface, iface, method.Name(), strings.Join(args, ""))
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(&g.code, "\t%s\n}\n", call)
+		return
+	}
+	_, _ = fmt.Fprintf(&g.code, "\tr := %s\n\treturn %s\n}\n", call, strings.Join(returns, ", "))
+}
+
+// typeString renders the type, importing the packages of other named types
+func (g *generator) typeString(typ types.Type) string {
+	return types.TypeString(typ, func(pkg *types.Package) string {
+		if pkg.Path() == g.pkg.Path() {
+			return ""
+		}
+		if alias, ok := g.imports[pkg.Path()]; ok {
+			return alias
+		}
+
+		alias := pkg.Name()
+		for i := 2; g.aliases[alias] || g.pkg.Scope().Lookup(alias) != nil; i++ {
+			alias = pkg.Name() + strconv.Itoa(i)
+		}
+		g.imports[pkg.Path()] = alias
+		g.names[pkg.Path()] = pkg.Name()
+		g.aliases[alias] = true
+		return alias
+	})
+}
+
+// usedPackages returns the package names referenced in the generated code
+func usedPackages(code []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package generated\n"), code...), parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return used, nil
+}
+
+// writeImports writes the import declaration of the used packages, standard library packages first
+func (g *generator) writeImports(src *bytes.Buffer, used map[string]bool) {
+	paths := make([]string, 0, len(g.imports))
+	for path, alias := range g.imports {
+		if used[alias] {
+			paths = append(paths, path)
+		}
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		if std(a) != std(b) {
+			if std(a) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	src.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && std(path) != std(paths[i-1]) {
+			src.WriteString("\n")
+		}
+		alias := g.imports[path]
+		if alias == g.names[path] {
+			_, _ = fmt.Fprintf(src, "\t%q\n", path)
+		} else {
+			_, _ = fmt.Fprintf(src, "\t%s %q\n", alias, path)
+		}
+	}
+	src.WriteString(")\n\n")
+}
+
+// std checks if the import path belongs to the standard library
+func std(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
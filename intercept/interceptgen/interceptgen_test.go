@@ -0,0 +1,49 @@
+package interceptgen
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generated example is up to date", func(t *testing.T) {
+		t.Parallel()
+
+		src, err := Generate(Config{Dir: "../internal/example", Types: []string{"Greeter"}, Output: "intercept_gen.go"})
+		require.NoError(t, err)
+
+		expected, err := os.ReadFile("../internal/example/intercept_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(src), "run go generate ./intercept/...")
+	})
+
+	t.Run("parameterless methods", func(t *testing.T) {
+		t.Parallel()
+
+		src, err := Generate(Config{Dir: "../internal/example", Types: []string{"Closer"}, Output: "closer_gen.go"})
+		require.NoError(t, err)
+		assert.NotContains(t, string(src), `"reflect"`)
+
+		expected, err := os.ReadFile("../internal/example/closer_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(src), "run go generate ./intercept/...")
+	})
+
+	t.Run("invalid types", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Generate(Config{Dir: "../internal/example", Types: []string{"Hello"}})
+		assert.EqualError(t, err, "interceptgen: Hello: not an interface")
+
+		_, err = Generate(Config{Dir: "../internal/example", Types: []string{"Missing"}})
+		assert.EqualError(t, err, "interceptgen: Missing: type not found")
+
+		_, err = Generate(Config{Dir: "../internal/example"})
+		assert.EqualError(t, err, "interceptgen: no types given")
+	})
+}
@@ -0,0 +1,34 @@
+// Code generated by dingointercept. DO NOT EDIT.
+
+package example
+
+import (
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/intercept"
+)
+
+// CloserProxy intercepts all methods of Closer with the MethodInterceptors bound by intercept.BindMethodInterceptor,
+// or by intercept.BindAnnotatedMethodInterceptor for annotated bindings
+type CloserProxy struct {
+	Closer
+	Injector     *dingo.Injector `inject:""`
+	Interceptors []intercept.MethodInterceptor
+	element      *dingo.Element
+}
+
+// InterceptAnnotation resolves the MethodInterceptors for the annotation of the binding the proxy wraps
+func (p *CloserProxy) InterceptAnnotation(annotation string) (err error) {
+	p.Interceptors, err = intercept.Interceptors(p.Injector, new(Closer), annotation)
+	return err
+}
+
+// InterceptElement stores the multi binding or map binding element the proxy wraps
+func (p *CloserProxy) InterceptElement(element dingo.Element) {
+	p.element = &element
+}
+
+// Close calls Closer.Close through the interceptors
+func (p *CloserProxy) Close() error {
+	r := intercept.Call(new(Closer), p.Closer, p.element, "Close", p.Interceptors)
+	return intercept.Result[error](r, 0)
+}
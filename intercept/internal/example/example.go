@@ -0,0 +1,43 @@
+// Package example contains interfaces with proxies generated by dingointercept.
+package example
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+//go:generate go run flamingo.me/dingo/cmd/dingointercept -o intercept_gen.go -type Greeter
+//go:generate go run flamingo.me/dingo/cmd/dingointercept -o closer_gen.go -type Closer
+
+type (
+	// Greeter is intercepted by the generated GreeterProxy
+	Greeter interface {
+		Greet(ctx context.Context, name string) (string, error)
+		Join(separator string, names ...string) string
+		Write(w io.Writer)
+	}
+
+	// Closer has only parameterless methods, so its proxy does not need reflect
+	Closer interface {
+		Close() error
+	}
+
+	// Hello greets with hello
+	Hello struct{}
+)
+
+// Greet greets the name
+func (*Hello) Greet(_ context.Context, name string) (string, error) {
+	return "Hello " + name, nil
+}
+
+// Join joins the names with the separator
+func (*Hello) Join(separator string, names ...string) string {
+	return strings.Join(names, separator)
+}
+
+// Write writes hello to w
+func (*Hello) Write(w io.Writer) {
+	_, _ = io.WriteString(w, "hello")
+}
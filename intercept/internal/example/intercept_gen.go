@@ -0,0 +1,49 @@
+// Code generated by dingointercept. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"flamingo.me/dingo"
+	"flamingo.me/dingo/intercept"
+)
+
+// GreeterProxy intercepts all methods of Greeter with the MethodInterceptors bound by intercept.BindMethodInterceptor,
+// or by intercept.BindAnnotatedMethodInterceptor for annotated bindings
+type GreeterProxy struct {
+	Greeter
+	Injector     *dingo.Injector `inject:""`
+	Interceptors []intercept.MethodInterceptor
+	element      *dingo.Element
+}
+
+// InterceptAnnotation resolves the MethodInterceptors for the annotation of the binding the proxy wraps
+func (p *GreeterProxy) InterceptAnnotation(annotation string) (err error) {
+	p.Interceptors, err = intercept.Interceptors(p.Injector, new(Greeter), annotation)
+	return err
+}
+
+// InterceptElement stores the multi binding or map binding element the proxy wraps
+func (p *GreeterProxy) InterceptElement(element dingo.Element) {
+	p.element = &element
+}
+
+// Greet calls Greeter.Greet through the interceptors
+func (p *GreeterProxy) Greet(a0 context.Context, a1 string) (string, error) {
+	r := intercept.Call(new(Greeter), p.Greeter, p.element, "Greet", p.Interceptors, reflect.ValueOf(&a0).Elem(), reflect.ValueOf(&a1).Elem())
+	return intercept.Result[string](r, 0), intercept.Result[error](r, 1)
+}
+
+// Join calls Greeter.Join through the interceptors
+func (p *GreeterProxy) Join(a0 string, a1 ...string) string {
+	r := intercept.Call(new(Greeter), p.Greeter, p.element, "Join", p.Interceptors, reflect.ValueOf(&a0).Elem(), reflect.ValueOf(&a1).Elem())
+	return intercept.Result[string](r, 0)
+}
+
+// Write calls Greeter.Write through the interceptors
+func (p *GreeterProxy) Write(a0 io.Writer) {
+	intercept.Call(new(Greeter), p.Greeter, p.element, "Write", p.Interceptors, reflect.ValueOf(&a0).Elem())
+}
@@ -0,0 +1,179 @@
+package dingo
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+type (
+	// Element identifies the element of a multi binding or map binding an interceptor wraps
+	Element struct {
+		Annotation string      // annotation of the multi binding or map binding
+		Index      int         // index in the multi binding, -1 for map bindings
+		Key        interface{} // key in the map binding
+	}
+
+	// ElementInterceptor is implemented by interceptors which need to know the multi binding or map binding element
+	// they wrap. InterceptElement is called after the interceptor is injected, before it is used.
+	ElementInterceptor interface {
+		InterceptElement(element Element)
+	}
+
+	// AnnotationInterceptor is implemented by interceptors which need to know the annotation of the resolution they
+	// intercept, for elements the annotation of the multi binding or map binding. InterceptAnnotation is called after
+	// the interceptor is injected, before it is used.
+	AnnotationInterceptor interface {
+		InterceptAnnotation(annotation string) error
+	}
+)
+
+// InterceptorBinding restricts and orders an interceptor bound with BindInterceptor
+type InterceptorBinding struct {
+	typeof      reflect.Type
+	interceptor reflect.Type
+	injector    *Injector // the injector the interceptor was bound in, it injects the interceptor
+
+	annotated     bool
+	annotatedWith string
+	binding       *Binding
+	when          func(t reflect.Type, annotation string) bool
+	priority      int
+}
+
+// BindInterceptor intercepts to interface with interceptor.
+// By default all resolutions of the interface are intercepted, the returned InterceptorBinding restricts and orders it.
+func (injector *Injector) BindInterceptor(to, interceptor interface{}) *InterceptorBinding {
+	binding, err := injector.TryBindInterceptor(to, interceptor)
+	if err != nil {
+		panic(err)
+	}
+	return binding
+}
+
+// TryBindInterceptor is BindInterceptor, returning an ErrInvalidBinding error instead of panicking.
+// The interceptor must be a struct embedding the interface as first field, with a pointer implementing the interface.
+func (injector *Injector) TryBindInterceptor(to, interceptor interface{}) (*InterceptorBinding, error) {
+	totype, err := bindType(to)
+	if err != nil {
+		return nil, err
+	}
+	if totype.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%w: can only intercept interfaces %v", ErrInvalidBinding, to)
+	}
+	itype := reflect.TypeOf(interceptor)
+	if itype == nil || itype.Kind() != reflect.Struct || itype.NumField() == 0 || !totype.AssignableTo(itype.Field(0).Type) || !reflect.PtrTo(itype).Implements(totype) {
+		return nil, fmt.Errorf("%w: interceptor %v for %s must be a struct embedding the interface as first field", ErrInvalidBinding, itype, totype)
+	}
+
+	binding := &InterceptorBinding{typeof: totype, interceptor: itype, injector: injector}
+	injector.interceptor[totype] = append(injector.interceptor[totype], binding)
+	return binding, nil
+}
+
+// AnnotatedWith restricts the interceptor to resolutions of the interface with the annotation,
+// an empty annotation restricts it to resolutions without annotation
+func (b *InterceptorBinding) AnnotatedWith(annotation string) *InterceptorBinding {
+	b.annotated = true
+	b.annotatedWith = annotation
+	return b
+}
+
+// ForBinding restricts the interceptor to resolutions of the binding
+func (b *InterceptorBinding) ForBinding(binding *Binding) *InterceptorBinding {
+	b.binding = binding
+	return b
+}
+
+// When restricts the interceptor to resolutions the predicate accepts
+func (b *InterceptorBinding) When(predicate func(t reflect.Type, annotation string) bool) *InterceptorBinding {
+	b.when = predicate
+	return b
+}
+
+// WithPriority orders the interceptors of a type: interceptors with a higher priority wrap those with a lower priority,
+// so they are called first. Interceptors with the same priority, 0 by default, wrap those bound before them,
+// and interceptors of a parent injector wrap those of the child.
+func (b *InterceptorBinding) WithPriority(priority int) *InterceptorBinding {
+	b.priority = priority
+	return b
+}
+
+// Interceptor returns the type of the interceptor
+func (b *InterceptorBinding) Interceptor() reflect.Type {
+	return b.interceptor
+}
+
+// Priority returns the priority of the interceptor
+func (b *InterceptorBinding) Priority() int {
+	return b.priority
+}
+
+func (b *InterceptorBinding) matches(annotation string, binding *Binding) bool {
+	if b.annotated && b.annotatedWith != annotation {
+		return false
+	}
+	if b.binding != nil && b.binding != binding {
+		return false
+	}
+	if b.when != nil && !b.when(b.typeof, annotation) {
+		return false
+	}
+	return true
+}
+
+// interceptors returns the interceptors applied to a resolution, from the innermost to the outermost
+func (injector *Injector) interceptors(t reflect.Type, annotation string, binding *Binding) []*InterceptorBinding {
+	var chain []*InterceptorBinding
+	for current := injector; current != nil; current = current.parent {
+		for _, interceptor := range current.interceptor[t] {
+			if interceptor.matches(annotation, binding) {
+				chain = append(chain, interceptor)
+			}
+		}
+	}
+
+	slices.SortStableFunc(chain, func(a, b *InterceptorBinding) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+	return chain
+}
+
+// InterceptorChain returns the interceptors applied when the type is resolved with the annotation, in the order they
+// are called: the first interceptor wraps all others.
+func (injector *Injector) InterceptorChain(of interface{}, annotation string) []*InterceptorBinding {
+	t := typeOf(of)
+	chain := injector.interceptors(t, annotation, injector.findBindingForAnnotatedType(t, annotation))
+	slices.Reverse(chain)
+	return chain
+}
+
+func (injector *Injector) intercept(final reflect.Value, t reflect.Type, annotation string, binding *Binding) (reflect.Value, error) {
+	return injector.interceptElement(final, t, annotation, binding, nil)
+}
+
+// interceptElement applies the interceptors, and informs AnnotationInterceptors and ElementInterceptors about what they wrap
+func (injector *Injector) interceptElement(final reflect.Value, t reflect.Type, annotation string, binding *Binding, element *Element) (reflect.Value, error) {
+	for _, interceptor := range injector.interceptors(t, annotation, binding) {
+		of := final
+		final = reflect.New(interceptor.interceptor)
+		if err := interceptor.injector.requestInjection(final.Interface(), interceptor.injector.circularTrace()); err != nil {
+			return reflect.Value{}, err
+		}
+		final.Elem().Field(0).Set(of)
+		if ai, ok := final.Interface().(AnnotationInterceptor); ok {
+			if err := ai.InterceptAnnotation(annotation); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		if ei, ok := final.Interface().(ElementInterceptor); ok && element != nil {
+			ei.InterceptElement(*element)
+		}
+
+		if len(injector.observers) > 0 {
+			injector.observe(Event{Kind: EventInterceptorApplied, Type: t, Interceptor: interceptor.interceptor})
+		}
+	}
+	return final, nil
+}
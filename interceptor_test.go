@@ -0,0 +1,180 @@
+package dingo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AopInterceptor3 struct {
+	AopInterface
+}
+
+func (a *AopInterceptor3) Test() string {
+	return a.AopInterface.Test() + " 3"
+}
+
+func TestInterceptorBinding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("annotation, binding and predicate", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(AopInterface)).To(AopImpl{})
+			injector.Bind(new(AopInterface)).AnnotatedWith("a").To(AopImpl{})
+			b := injector.Bind(new(AopInterface)).AnnotatedWith("b").To(AopImpl{})
+
+			injector.BindInterceptor(new(AopInterface), AopInterceptor1{}).AnnotatedWith("a")
+			injector.BindInterceptor(new(AopInterface), AopInterceptor2{}).ForBinding(b)
+			injector.BindInterceptor(new(AopInterface), AopInterceptor3{}).When(func(_ reflect.Type, annotation string) bool {
+				return annotation == ""
+			})
+		}))
+		require.NoError(t, err)
+
+		for annotation, expected := range map[string]string{"": "Test 3", "a": "Test 1", "b": "Test 2"} {
+			i, err := injector.GetAnnotatedInstance(new(AopInterface), annotation)
+			require.NoError(t, err)
+			assert.Equal(t, expected, i.(AopInterface).Test(), annotation)
+		}
+	})
+
+	t.Run("priority and chain", func(t *testing.T) {
+		t.Parallel()
+
+		parent, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(AopInterface)).To(AopImpl{})
+			injector.BindInterceptor(new(AopInterface), AopInterceptor1{})
+		}))
+		require.NoError(t, err)
+
+		injector, err := parent.Child()
+		require.NoError(t, err)
+		injector.BindInterceptor(new(AopInterface), AopInterceptor2{})
+		injector.BindInterceptor(new(AopInterface), AopInterceptor3{}).WithPriority(-1)
+
+		i, err := injector.GetInstance(new(AopInterface))
+		require.NoError(t, err)
+		assert.Equal(t, "Test 3 2 1", i.(AopInterface).Test())
+
+		var chain []reflect.Type
+		for _, interceptor := range injector.InterceptorChain(new(AopInterface), "") {
+			chain = append(chain, interceptor.Interceptor())
+		}
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(AopInterceptor1{}), reflect.TypeOf(AopInterceptor2{}), reflect.TypeOf(AopInterceptor3{})}, chain)
+
+		injector.BindInterceptor(new(AopInterface), AopInterceptor3{}).WithPriority(1)
+		i, err = injector.GetInstance(new(AopInterface))
+		require.NoError(t, err)
+		assert.Equal(t, "Test 3 2 1 3", i.(AopInterface).Test())
+		assert.Equal(t, 1, injector.InterceptorChain(new(AopInterface), "")[0].Priority())
+
+		assert.Contains(t, injector.Explain(new(AopInterface), ""), "intercepted by dingo.AopInterceptor3 (this injector, priority 1)\n  intercepted by dingo.AopInterceptor1 (parent injector 1)")
+	})
+}
+
+type AopElementInterceptor struct {
+	AopInterface
+	element Element
+}
+
+func (a *AopElementInterceptor) InterceptElement(element Element) {
+	a.element = element
+}
+
+func (a *AopElementInterceptor) Test() string {
+	var key string
+	if a.element.Key != nil {
+		key = fmt.Sprint(a.element.Key)
+	}
+	return fmt.Sprintf("%s %q/%d/%s", a.AopInterface.Test(), a.element.Annotation, a.element.Index, key)
+}
+
+type AopInterfaceProvider func() AopInterface
+
+type AopAnnotationInterceptor struct {
+	AopInterface
+	annotation string
+}
+
+func (a *AopAnnotationInterceptor) InterceptAnnotation(annotation string) error {
+	if annotation == "invalid" {
+		return errors.New("invalid annotation")
+	}
+	a.annotation = annotation
+	return nil
+}
+
+func (a *AopAnnotationInterceptor) Test() string {
+	return fmt.Sprintf("%s %q", a.AopInterface.Test(), a.annotation)
+}
+
+func TestInterceptElements(t *testing.T) {
+	t.Parallel()
+
+	injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+		injector.BindMulti(new(AopInterface)).To(AopImpl{})
+		injector.BindMulti(new(AopInterface)).ToInstance(new(AopImpl))
+		injector.BindMulti(new(AopInterface)).AnnotatedWith("plugins").To(AopImpl{})
+		injector.BindMap(new(AopInterface), "key").To(AopImpl{})
+
+		injector.BindInterceptor(new(AopInterface), AopInterceptor1{})
+		injector.BindInterceptor(new(AopInterface), AopElementInterceptor{})
+	}))
+	require.NoError(t, err)
+
+	var dep struct {
+		List         []AopInterface                  `inject:""`
+		Plugins      []AopInterface                  `inject:"plugins"`
+		Map          map[string]AopInterface         `inject:""`
+		ListProvider []AopInterfaceProvider          `inject:""`
+		MapProvider  map[string]AopInterfaceProvider `inject:""`
+	}
+	require.NoError(t, injector.RequestInjection(&dep))
+
+	require.Len(t, dep.List, 2)
+	assert.Equal(t, `Test 1 ""/0/`, dep.List[0].Test())
+	assert.Equal(t, `Test 1 ""/1/`, dep.List[1].Test())
+	require.Len(t, dep.Plugins, 1)
+	assert.Equal(t, `Test 1 "plugins"/0/`, dep.Plugins[0].Test())
+	assert.Equal(t, `Test 1 ""/-1/key`, dep.Map["key"].Test())
+
+	require.Len(t, dep.ListProvider, 2)
+	assert.Equal(t, `Test 1 ""/1/`, dep.ListProvider[1]().Test())
+	assert.Equal(t, `Test 1 ""/-1/key`, dep.MapProvider["key"]().Test())
+}
+
+func TestInterceptAnnotation(t *testing.T) {
+	t.Parallel()
+
+	injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+		injector.Bind(new(AopInterface)).To(AopImpl{})
+		injector.Bind(new(AopInterface)).AnnotatedWith("a").To(AopImpl{})
+		injector.Bind(new(AopInterface)).AnnotatedWith("invalid").To(AopImpl{})
+		injector.BindMulti(new(AopInterface)).AnnotatedWith("plugins").To(AopImpl{})
+
+		injector.BindInterceptor(new(AopInterface), AopAnnotationInterceptor{})
+	}))
+	require.NoError(t, err)
+
+	for _, annotation := range []string{"", "a"} {
+		i, err := injector.GetAnnotatedInstance(new(AopInterface), annotation)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("Test %q", annotation), i.(AopInterface).Test())
+	}
+
+	var dep struct {
+		Plugins []AopInterface `inject:"plugins"`
+	}
+	require.NoError(t, injector.RequestInjection(&dep))
+	require.Len(t, dep.Plugins, 1)
+	assert.Equal(t, `Test "plugins"`, dep.Plugins[0].Test())
+
+	_, err = injector.GetAnnotatedInstance(new(AopInterface), "invalid")
+	assert.ErrorContains(t, err, "invalid annotation")
+}
@@ -0,0 +1,91 @@
+package dingo
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// seqType describes an injected iter.Seq or iter.Seq2
+type seqType struct {
+	key      reflect.Type // key type of iter.Seq2[K, T] over map bindings, nil for multi bindings
+	elem     reflect.Type // element type
+	canError bool         // iter.Seq2[T, error] yields resolution errors instead of panicking
+}
+
+// iterSeq checks if the type is an iter.Seq[T], iter.Seq2[T, error] or iter.Seq2[K, T].
+// iter.Seq2[T, error] takes precedence, so map bindings of error values can not be iterated lazily.
+func iterSeq(t reflect.Type) (seqType, bool) {
+	if t.Kind() != reflect.Func || t.PkgPath() != "iter" || t.NumIn() != 1 || t.NumOut() != 0 {
+		return seqType{}, false
+	}
+
+	yield := t.In(0)
+	switch {
+	case yield.NumIn() == 1:
+		return seqType{elem: yield.In(0)}, true
+	case yield.NumIn() == 2 && yield.In(1) == reflect.TypeOf(new(error)).Elem():
+		return seqType{elem: yield.In(0), canError: true}, true
+	case yield.NumIn() == 2:
+		return seqType{key: yield.In(0), elem: yield.In(1)}, true
+	}
+	return seqType{}, false
+}
+
+// resolveSeq creates an iterator over the multi bindings or map bindings of the element type.
+// Every element is resolved when the iteration reaches it, map bindings are iterated in the order of their keys.
+func (injector *Injector) resolveSeq(t reflect.Type, seq seqType, annotation string, optional bool) reflect.Value {
+	var circularTrace []circularTraceEntry
+	if injector.circularTracing() {
+		circularTrace = make([]circularTraceEntry, 0)
+	}
+
+	targetType := derefType(seq.elem)
+
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		yield := func(key reflect.Value, element *Element, binding *Binding) bool {
+			r, err := injector.resolveElement(binding, t, targetType, element, optional, circularTrace)
+			switch {
+			case seq.canError:
+				if err != nil {
+					r = reflect.Zero(seq.elem)
+				}
+				return args[0].Call([]reflect.Value{r, reflectedError(&err, t)})[0].Bool()
+			case err != nil:
+				return injector.seqFailed(t, err)
+			case seq.key != nil:
+				return args[0].Call([]reflect.Value{key, r})[0].Bool()
+			default:
+				return args[0].Call([]reflect.Value{r})[0].Bool()
+			}
+		}
+
+		if seq.key != nil {
+			bindings := injector.joinMapbindings(targetType, seq.key, annotation)
+			for _, key := range sortedMapKeys(bindings) {
+				if !yield(reflect.ValueOf(key), &Element{Annotation: annotation, Index: -1, Key: key}, bindings[key]) {
+					return nil
+				}
+			}
+			return nil
+		}
+
+		for i, binding := range injector.joinMultibindings(targetType, annotation) {
+			if !yield(reflect.Value{}, &Element{Annotation: annotation, Index: i}, binding) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// seqFailed handles a resolution error of an iterator which can not yield errors, like providerFailed.
+// With safe providers the element is skipped and the iteration continues.
+func (injector *Injector) seqFailed(t reflect.Type, err error) bool {
+	if !injector.options.safeProviders {
+		panic(fmt.Errorf("%q: %w", t, err))
+	}
+
+	injector.logger().Error("dingo: iterator element failed, skipping it", slog.String("iterator", t.String()), slog.Any("error", err))
+	return true
+}
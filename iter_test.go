@@ -0,0 +1,140 @@
+package dingo
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type iterFailing interface {
+	setListener
+	Fail()
+}
+
+func TestIterSeq(t *testing.T) {
+	t.Parallel()
+
+	var created []string
+	plugin := func(name string) func() setListener {
+		return func() setListener {
+			created = append(created, name)
+			return setListenerA{}
+		}
+	}
+
+	injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+		injector.BindMulti(new(setListener)).ToProvider(plugin("first"))
+		injector.BindMulti(new(setListener)).ToProvider(plugin("second"))
+		injector.BindMulti(new(setListener)).AnnotatedWith("failing").To(new(iterFailing))
+		injector.BindMulti(new(setListener)).AnnotatedWith("failing").To(setListenerB{})
+
+		injector.BindMap(new(setListener), "b").ToProvider(plugin("b"))
+		injector.BindMap(new(setListener), "a").ToProvider(plugin("a"))
+		injector.BindMap(new(setListener), mapBindKind(1)).To(setListenerB{})
+	}))
+	require.NoError(t, err)
+
+	t.Run("multi bindings are resolved lazily", func(t *testing.T) {
+		created = nil
+
+		i, err := injector.GetInstance(new(iter.Seq[setListener]))
+		require.NoError(t, err)
+		assert.Empty(t, created)
+
+		for listener := range i.(iter.Seq[setListener]) {
+			assert.Equal(t, "a", listener.Name())
+			break
+		}
+		assert.Equal(t, []string{"first"}, created)
+	})
+
+	t.Run("map bindings are iterated by key", func(t *testing.T) {
+		created = nil
+
+		i, err := injector.GetInstance(new(iter.Seq2[string, setListener]))
+		require.NoError(t, err)
+
+		var keys []string
+		for key := range i.(iter.Seq2[string, setListener]) {
+			keys = append(keys, key)
+		}
+		assert.Equal(t, []string{"a", "b"}, keys)
+		assert.Equal(t, []string{"a", "b"}, created)
+
+		i, err = injector.GetInstance(new(iter.Seq2[mapBindKind, setListener]))
+		require.NoError(t, err)
+		for key, listener := range i.(iter.Seq2[mapBindKind, setListener]) {
+			assert.Equal(t, mapBindKind(1), key)
+			assert.Equal(t, "b", listener.Name())
+		}
+	})
+
+	t.Run("resolution errors", func(t *testing.T) {
+		i, err := injector.GetAnnotatedInstance(new(iter.Seq2[setListener, error]), "failing")
+		require.NoError(t, err)
+
+		var names []string
+		var errs []error
+		for listener, err := range i.(iter.Seq2[setListener, error]) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			names = append(names, listener.Name())
+		}
+		assert.Equal(t, []string{"b"}, names)
+		require.Len(t, errs, 1)
+		var unbound *UnboundError
+		assert.ErrorAs(t, errs[0], &unbound)
+
+		i, err = injector.GetAnnotatedInstance(new(iter.Seq[setListener]), "failing")
+		require.NoError(t, err)
+		assert.Panics(t, func() {
+			for range i.(iter.Seq[setListener]) {
+			}
+		})
+	})
+
+	t.Run("safe providers skip failing elements", func(t *testing.T) {
+		injector, err := NewInjectorWithOptions(WithSafeProviders(), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(setListener)).To(new(iterFailing))
+			injector.BindMulti(new(setListener)).To(setListenerB{})
+		})))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(iter.Seq[setListener]))
+		require.NoError(t, err)
+
+		var names []string
+		for listener := range i.(iter.Seq[setListener]) {
+			names = append(names, listener.Name())
+		}
+		assert.Equal(t, []string{"b"}, names)
+	})
+
+	t.Run("error elements yield multi bindings", func(t *testing.T) {
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMap(new(error), "map").ToInstance(errors.New("map binding"))
+			injector.BindMulti(new(string)).ToInstance("multi binding")
+		}))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(iter.Seq2[string, error]))
+		require.NoError(t, err)
+
+		var elements []string
+		for element, err := range i.(iter.Seq2[string, error]) {
+			require.NoError(t, err)
+			elements = append(elements, element)
+		}
+		assert.Equal(t, []string{"multi binding"}, elements)
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		assert.Contains(t, injector.Explain(new(iter.Seq[setListener]), ""), "lazy multibinding with 2 element(s)")
+		assert.Contains(t, injector.Explain(new(iter.Seq2[string, setListener]), ""), "lazy map binding with 2 element(s)")
+	})
+}
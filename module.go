@@ -30,6 +30,12 @@ type (
 	Depender interface {
 		Depends() []Module
 	}
+
+	// ModuleIdentifier is implemented by Modules which are configured once per identity instead of once per type.
+	// This allows a module type to be configured several times with different identities.
+	ModuleIdentifier interface {
+		ModuleIdentity() string
+	}
 )
 
 var (
@@ -67,11 +73,10 @@ func TryModule(modules ...Module) (resultingError error) {
 		}
 	}()
 
-	injector, err := NewInjector()
+	injector, err := NewInjectorWithOptions(WithEagerSingletons(false))
 	if err != nil {
 		return err
 	}
-	injector.buildEagerSingletons = false
 	return injector.InitModules(modules...)
 }
 
@@ -87,8 +92,8 @@ func newModuleGraph() *modGraph {
 }
 
 // Add adds each module and its transitive dependencies to the graph.
-// A module that is already present (identified by its type or by a pointer
-// for ModuleFunc) is skipped — only the first instance is kept.
+// A module that is already present (identified by its type, its ModuleIdentity
+// or by a pointer for ModuleFunc) is skipped — only the first instance is kept.
 func (mg *modGraph) Add(modules ...Module) error {
 	for _, module := range modules {
 		_, err := mg.addModule(module)
@@ -184,7 +189,8 @@ func (mg *modGraph) addModule(module Module) (int64, error) {
 }
 
 // moduleIdentity returns a stable string key that uniquely identifies a module.
-// For ordinary module types the key is the fully qualified type name.
+// For ordinary module types the key is the fully qualified type name,
+// for ModuleIdentifiers it is combined with their identity.
 // For ModuleFunc values the function pointer address is included so that
 // two distinct func literals are treated as different modules.
 func moduleIdentity(module Module) string {
@@ -193,6 +199,9 @@ func moduleIdentity(module Module) string {
 		value := reflect.ValueOf(module)
 		return fmt.Sprintf("%s_%d", value.Type(), value.Pointer())
 	}
+	if identifier, ok := module.(ModuleIdentifier); ok {
+		return modType.String() + "_" + identifier.ModuleIdentity()
+	}
 
 	return modType.String()
 }
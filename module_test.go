@@ -58,6 +58,31 @@ func TestResolveDependenciesWithModuleFunc(t *testing.T) {
 	assert.Equal(t, 1, countExtern, "variable defined modules should only be called once")
 }
 
+type identifiedModule struct {
+	name       string
+	configured *[]string
+}
+
+func (m *identifiedModule) Configure(*Injector) {
+	*m.configured = append(*m.configured, m.name)
+}
+
+func (m *identifiedModule) ModuleIdentity() string {
+	return m.name
+}
+
+func TestModuleIdentifier(t *testing.T) {
+	var configured []string
+
+	_, err := NewInjector(
+		&identifiedModule{name: "b", configured: &configured},
+		&identifiedModule{name: "a", configured: &configured},
+		&identifiedModule{name: "b", configured: &configured},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, configured, "modules are configured once per identity, ordered by it")
+}
+
 type (
 	resolveDependenciesModuleA  struct{}
 	resolveDependenciesModuleB  struct{}
@@ -1,6 +1,7 @@
 package dingo
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -270,3 +271,60 @@ func TestMultiBindingSingleton(t *testing.T) {
 
 	assert.Same(t, first, second)
 }
+
+type (
+	mapBindKind int
+
+	mapBindKey struct {
+		Name    string
+		Version int
+	}
+
+	mapBindKindProvider func() mapBindInterface
+)
+
+func TestMapBindingKeyTypes(t *testing.T) {
+	injector, err := NewInjector()
+	assert.NoError(t, err)
+
+	injector.BindMap(new(mapBindInterface), "string").ToInstance("string")
+	injector.BindMap(new(mapBindInterface), mapBindKind(1)).ToInstance("kind 1")
+	injector.BindMap(new(mapBindInterface), mapBindKey{Name: "a", Version: 2}).ToInstance("a v2")
+	injector.BindMap(new(mapBindInterface), reflect.TypeOf("")).ToInstance("string type")
+
+	child, err := injector.Child()
+	assert.NoError(t, err)
+	child.BindMap(new(mapBindInterface), mapBindKind(1)).ToInstance("child kind 1")
+	child.BindMap(new(mapBindInterface), mapBindKind(2)).ToInstance("child kind 2")
+
+	i, err := injector.GetInstance(new(map[mapBindKind]mapBindInterface))
+	assert.NoError(t, err)
+	assert.Equal(t, map[mapBindKind]mapBindInterface{1: "kind 1"}, i)
+
+	i, err = child.GetInstance(new(map[mapBindKind]mapBindInterface))
+	assert.NoError(t, err)
+	assert.Equal(t, map[mapBindKind]mapBindInterface{1: "child kind 1", 2: "child kind 2"}, i)
+
+	i, err = child.GetInstance(new(map[mapBindKind]mapBindKindProvider))
+	assert.NoError(t, err)
+	providers := i.(map[mapBindKind]mapBindKindProvider)
+	assert.Len(t, providers, 2)
+	assert.Equal(t, "child kind 2", providers[2]())
+
+	i, err = injector.GetInstance(new(map[mapBindKey]mapBindInterface))
+	assert.NoError(t, err)
+	assert.Equal(t, map[mapBindKey]mapBindInterface{{Name: "a", Version: 2}: "a v2"}, i)
+
+	i, err = injector.GetInstance(new(map[reflect.Type]mapBindInterface))
+	assert.NoError(t, err)
+	assert.Equal(t, map[reflect.Type]mapBindInterface{reflect.TypeOf(""): "string type"}, i)
+
+	i, err = child.GetInstance(new(map[string]mapBindInterface))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]mapBindInterface{"string": "string"}, i)
+
+	_, err = injector.TryBindMap(new(mapBindInterface), nil)
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	_, err = injector.TryBindMap(new(mapBindInterface), []string{"a"})
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+}
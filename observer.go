@@ -0,0 +1,121 @@
+package dingo
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type (
+	// EventKind describes what an Event is about
+	EventKind int
+
+	// BindingKind describes how a resolved type is created
+	BindingKind string
+
+	// Event is a structured resolution event, passed to all Observers of an injector
+	Event struct {
+		Kind        EventKind
+		Type        reflect.Type
+		Annotation  string
+		Scope       Scope         // scope of the binding, nil if unscoped
+		BindingKind BindingKind   // how the type is created
+		Provider    string        // provider function name for EventProviderCall
+		Interceptor reflect.Type  // applied interceptor for EventInterceptorApplied
+		Duration    time.Duration // duration for EventResolveEnd, EventProviderCall and EventSingletonCreated
+		Err         error         // resolution error for EventResolveEnd, EventProviderCall and EventSingletonCreated
+	}
+
+	// Observer receives resolution events of an injector.
+	// Observers are called synchronously during resolution and must be safe for concurrent use.
+	Observer interface {
+		Observe(event Event)
+	}
+
+	// ObserverFunc wraps a func(Event) to be used as an Observer
+	ObserverFunc func(event Event)
+)
+
+const (
+	// EventResolveStart is emitted before a type is resolved
+	EventResolveStart EventKind = iota
+	// EventResolveEnd is emitted after a type is resolved, with duration and error
+	EventResolveEnd
+	// EventProviderCall is emitted after a bound provider function was called
+	EventProviderCall
+	// EventSingletonCreated is emitted after a scope created a new instance
+	EventSingletonCreated
+	// EventInterceptorApplied is emitted after an interceptor was wrapped around an instance
+	EventInterceptorApplied
+)
+
+const (
+	// BindingKindJustInTime is used for concrete types created without binding
+	BindingKindJustInTime BindingKind = "just-in-time"
+	// BindingKindUntargeted is used for bindings without To, ToProvider or ToInstance
+	BindingKindUntargeted BindingKind = "untargeted"
+	// BindingKindTo is used for bindings created with To
+	BindingKindTo BindingKind = "to"
+	// BindingKindProvider is used for bindings created with ToProvider
+	BindingKindProvider BindingKind = "provider"
+	// BindingKindInstance is used for bindings created with ToInstance
+	BindingKindInstance BindingKind = "instance"
+	// BindingKindAutoProvider is used for automatically created Provider functions
+	BindingKindAutoProvider BindingKind = "auto-provider"
+	// BindingKindMulti is used for multibinding slices
+	BindingKindMulti BindingKind = "multibinding"
+	// BindingKindMap is used for map bindings
+	BindingKindMap BindingKind = "mapbinding"
+)
+
+var eventKindNames = map[EventKind]string{
+	EventResolveStart:       "resolve start",
+	EventResolveEnd:         "resolve end",
+	EventProviderCall:       "provider call",
+	EventSingletonCreated:   "singleton created",
+	EventInterceptorApplied: "interceptor applied",
+}
+
+// String returns a readable name of the event kind
+func (k EventKind) String() string {
+	return eventKindNames[k]
+}
+
+// Observe calls the wrapped function
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+// AddObserver registers an Observer for all resolution events of this injector and its future children
+func (injector *Injector) AddObserver(observer Observer) {
+	injector.observers = append(injector.observers, observer)
+}
+
+func (injector *Injector) observe(event Event) {
+	for _, observer := range injector.observers {
+		observer.Observe(event)
+	}
+}
+
+func bindingKindOf(t reflect.Type, binding *Binding) BindingKind {
+	switch {
+	case binding == nil || binding.typeof != t:
+		switch {
+		case t.Kind() == reflect.Func && strings.HasSuffix(t.Name(), "Provider"):
+			return BindingKindAutoProvider
+		case t.Kind() == reflect.Slice:
+			return BindingKindMulti
+		case t.Kind() == reflect.Map:
+			return BindingKindMap
+		}
+		return BindingKindJustInTime
+	case binding.instance != nil:
+		return BindingKindInstance
+	case binding.provider != nil:
+		return BindingKindProvider
+	case binding.to != nil:
+		return BindingKindTo
+	}
+
+	return BindingKindUntargeted
+}
@@ -0,0 +1,60 @@
+package observer
+
+import (
+	"expvar"
+
+	"flamingo.me/dingo"
+)
+
+// Expvar counts resolution events in an expvar.Map
+//
+// The map contains the counters "resolves", "resolve_errors", "resolve_ns", "provider_calls",
+// "singletons_created" and "interceptors_applied", as well as the map "types" counting resolves per type.
+type Expvar struct {
+	vars  *expvar.Map
+	types *expvar.Map
+}
+
+var _ dingo.Observer = new(Expvar)
+
+// NewExpvar publishes the counters with the given name, an already published expvar.Map with this name is reused
+func NewExpvar(name string) *Expvar {
+	vars, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		vars = expvar.NewMap(name)
+	}
+
+	types, ok := vars.Get("types").(*expvar.Map)
+	if !ok {
+		types = new(expvar.Map).Init()
+		vars.Set("types", types)
+	}
+
+	return &Expvar{vars: vars, types: types}
+}
+
+// Observe counts the event
+func (e *Expvar) Observe(event dingo.Event) {
+	switch event.Kind {
+	case dingo.EventResolveStart:
+		return
+	case dingo.EventResolveEnd:
+		e.vars.Add("resolves", 1)
+		e.vars.Add("resolve_ns", event.Duration.Nanoseconds())
+		e.types.Add(event.Type.String(), 1)
+		if event.Err != nil {
+			e.vars.Add("resolve_errors", 1)
+		}
+	case dingo.EventProviderCall:
+		e.vars.Add("provider_calls", 1)
+	case dingo.EventSingletonCreated:
+		e.vars.Add("singletons_created", 1)
+	case dingo.EventInterceptorApplied:
+		e.vars.Add("interceptors_applied", 1)
+	}
+}
+
+// Map returns the published expvar.Map
+func (e *Expvar) Map() *expvar.Map {
+	return e.vars
+}
@@ -0,0 +1,76 @@
+// Package observer provides reference implementations of dingo.Observer
+// for structured logging, expvar metrics and slow resolution detection.
+package observer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"flamingo.me/dingo"
+)
+
+// Logger logs every resolution event as a structured slog record
+type Logger struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+var _ dingo.Observer = new(Logger)
+
+// NewLogger creates a Logger writing events with the given level, events with errors are logged with slog.LevelError
+func NewLogger(logger *slog.Logger, level slog.Level) *Logger {
+	return &Logger{logger: logger, level: level}
+}
+
+// Observe logs the event
+func (l *Logger) Observe(event dingo.Event) {
+	level := l.level
+	if event.Err != nil {
+		level = slog.LevelError
+	}
+
+	if !l.logger.Enabled(context.Background(), level) {
+		return
+	}
+
+	l.logger.LogAttrs(context.Background(), level, "dingo: "+event.Kind.String(), Attrs(event)...)
+}
+
+// Attrs returns the slog attributes describing the event
+func Attrs(event dingo.Event) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("type", event.Type.String()),
+		slog.String("binding", string(event.BindingKind)),
+	}
+
+	if event.Annotation != "" {
+		attrs = append(attrs, slog.String("annotation", event.Annotation))
+	}
+
+	if event.Scope != nil {
+		attrs = append(attrs, slog.String("scope", scopeName(event.Scope)))
+	}
+
+	if event.Provider != "" {
+		attrs = append(attrs, slog.String("provider", event.Provider))
+	}
+
+	if event.Interceptor != nil {
+		attrs = append(attrs, slog.String("interceptor", event.Interceptor.String()))
+	}
+
+	if event.Kind != dingo.EventResolveStart && event.Kind != dingo.EventInterceptorApplied {
+		attrs = append(attrs, slog.Duration("duration", event.Duration))
+	}
+
+	if event.Err != nil {
+		attrs = append(attrs, slog.Any("error", event.Err))
+	}
+
+	return attrs
+}
+
+func scopeName(scope dingo.Scope) string {
+	return fmt.Sprintf("%T", scope)
+}
@@ -0,0 +1,71 @@
+package observer
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/dingo"
+)
+
+type observed struct{}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	logger := NewLogger(slog.New(slog.NewTextHandler(buf, nil)), slog.LevelInfo)
+
+	logger.Observe(dingo.Event{Kind: dingo.EventResolveEnd, Type: reflect.TypeOf(observed{}), Annotation: "a", BindingKind: dingo.BindingKindTo, Scope: dingo.Singleton, Duration: time.Second})
+	assert.Contains(t, buf.String(), `level=INFO msg="dingo: resolve end" type=observer.observed binding=to annotation=a scope=*dingo.SingletonScope duration=1s`)
+
+	buf.Reset()
+	logger.Observe(dingo.Event{Kind: dingo.EventResolveEnd, Type: reflect.TypeOf(observed{}), Err: errors.New("failed")})
+	assert.Contains(t, buf.String(), "level=ERROR")
+	assert.Contains(t, buf.String(), "error=failed")
+}
+
+func TestExpvar(t *testing.T) {
+	t.Parallel()
+
+	injector, err := dingo.NewInjector()
+	require.NoError(t, err)
+
+	name := "dingo_test_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	counter := NewExpvar(name)
+	assert.Same(t, counter.Map(), NewExpvar(name).Map())
+
+	injector.AddObserver(counter)
+	injector.Bind(new(string)).ToProvider(func() string { return "test" })
+
+	_, err = injector.GetInstance(new(observed))
+	require.NoError(t, err)
+	_, err = injector.GetInstance(new(string))
+	require.NoError(t, err)
+
+	assert.Equal(t, "2", counter.Map().Get("resolves").String())
+	assert.Equal(t, "1", counter.Map().Get("provider_calls").String())
+	assert.Nil(t, counter.Map().Get("resolve_errors"))
+	assert.Equal(t, `{"observer.observed": 1, "string": 1}`, counter.Map().Get("types").String())
+}
+
+func TestSlowResolution(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	slow := NewSlowResolution(10*time.Millisecond, slog.New(slog.NewTextHandler(buf, nil)))
+
+	slow.Observe(dingo.Event{Kind: dingo.EventResolveEnd, Type: reflect.TypeOf(observed{}), Duration: time.Millisecond})
+	slow.Observe(dingo.Event{Kind: dingo.EventResolveStart, Type: reflect.TypeOf(observed{}), Duration: time.Second})
+	assert.Empty(t, buf.String())
+
+	slow.Observe(dingo.Event{Kind: dingo.EventProviderCall, Type: reflect.TypeOf(observed{}), Provider: "pkg.provider", Duration: 20 * time.Millisecond})
+	assert.Contains(t, buf.String(), `level=WARN msg="dingo: slow provider call" type=observer.observed binding="" provider=pkg.provider duration=20ms`)
+}
@@ -0,0 +1,38 @@
+package observer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"flamingo.me/dingo"
+)
+
+// SlowResolution warns about resolutions and provider calls taking longer than a threshold.
+// The duration of a resolution includes the resolution of its dependencies,
+// so a slow dependency is reported together with all types depending on it.
+type SlowResolution struct {
+	threshold time.Duration
+	logger    *slog.Logger
+}
+
+var _ dingo.Observer = new(SlowResolution)
+
+// NewSlowResolution creates a SlowResolution observer logging warnings to the given logger
+func NewSlowResolution(threshold time.Duration, logger *slog.Logger) *SlowResolution {
+	return &SlowResolution{threshold: threshold, logger: logger}
+}
+
+// Observe warns if the event took longer than the threshold
+func (s *SlowResolution) Observe(event dingo.Event) {
+	if event.Kind != dingo.EventResolveEnd && event.Kind != dingo.EventProviderCall && event.Kind != dingo.EventSingletonCreated {
+		return
+	}
+
+	if event.Duration < s.threshold {
+		return
+	}
+
+	s.logger.LogAttrs(context.Background(), slog.LevelWarn, fmt.Sprintf("dingo: slow %s", event.Kind), Attrs(event)...)
+}
@@ -0,0 +1,135 @@
+package dingo
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	ObserverTestIface interface{}
+
+	observerImpl struct {
+		Dep *observerDep `inject:""`
+	}
+
+	observerDep struct{}
+
+	observerInterceptor struct {
+		ObserverTestIface
+	}
+
+	recordingObserver struct {
+		mu     sync.Mutex
+		events []Event
+	}
+)
+
+func (o *recordingObserver) Observe(event Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) kinds() []EventKind {
+	kinds := make([]EventKind, len(o.events))
+	for i, event := range o.events {
+		kinds[i] = event.Kind
+	}
+	return kinds
+}
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolve events", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		observer := new(recordingObserver)
+		injector.AddObserver(observer)
+
+		injector.Bind(new(ObserverTestIface)).To(observerImpl{})
+		injector.BindInterceptor(new(ObserverTestIface), observerInterceptor{})
+
+		_, err = injector.GetInstance(new(ObserverTestIface))
+		require.NoError(t, err)
+
+		assert.Equal(t, []EventKind{
+			EventResolveStart, // ObserverTestIface
+			EventResolveStart, // observerImpl
+			EventResolveStart, // observerDep
+			EventResolveEnd,
+			EventResolveEnd,
+			EventInterceptorApplied,
+			EventResolveEnd,
+		}, observer.kinds())
+
+		assert.Equal(t, BindingKindTo, observer.events[0].BindingKind)
+		assert.Equal(t, BindingKindJustInTime, observer.events[2].BindingKind)
+		assert.Equal(t, "dingo.observerDep", observer.events[3].Type.String())
+		assert.Equal(t, "dingo.ObserverTestIface", observer.events[6].Type.String())
+		assert.NoError(t, observer.events[6].Err)
+	})
+
+	t.Run("provider and singleton events", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		observer := new(recordingObserver)
+		injector.AddObserver(observer)
+
+		injector.Bind(new(string)).AnnotatedWith("test").In(Singleton).ToProvider(func() string { return "test" })
+		injector.BindScope(NewSingletonScope())
+
+		_, err = injector.GetAnnotatedInstance(new(string), "test")
+		require.NoError(t, err)
+
+		assert.Equal(t, []EventKind{EventResolveStart, EventProviderCall, EventSingletonCreated, EventResolveEnd}, observer.kinds())
+		assert.Equal(t, BindingKindProvider, observer.events[0].BindingKind)
+		assert.Equal(t, "test", observer.events[0].Annotation)
+		assert.Contains(t, observer.events[1].Provider, "TestObserver")
+		assert.IsType(t, new(SingletonScope), observer.events[2].Scope)
+	})
+
+	t.Run("inherited by child", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		observer := new(recordingObserver)
+		injector.AddObserver(observer)
+
+		child, err := injector.Child()
+		require.NoError(t, err)
+
+		_, err = child.GetInstance(new(observerDep))
+		require.NoError(t, err)
+
+		assert.Equal(t, []EventKind{EventResolveStart, EventResolveEnd}, observer.kinds())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		observer := new(recordingObserver)
+		injector.AddObserver(ObserverFunc(observer.Observe))
+
+		_, err = injector.GetInstance(new(ObserverTestIface))
+		require.Error(t, err)
+
+		require.Len(t, observer.events, 2)
+		assert.Equal(t, err, observer.events[1].Err)
+	})
+}
@@ -0,0 +1,118 @@
+package dingo
+
+import (
+	"context"
+	"log/slog"
+)
+
+type (
+	// Option configures an injector created by NewInjectorWithOptions
+	Option func(*options)
+
+	// options are inherited by child injectors
+	options struct {
+		logger                   *slog.Logger
+		circularTracing          bool
+		injectionTracing         bool
+		strict                   bool
+		strictAllow              []JustInTimeFilter
+		recoverPanics            bool
+		safeProviders            bool
+		buildEagerSingletons     bool
+		profilerLabels           bool
+		profilerContext          context.Context
+		eagerWorkers             int
+		deduplicateMultibindings bool
+		observers                []Observer
+		modules                  []Module
+	}
+)
+
+func defaultOptions() options {
+	return options{
+		buildEagerSingletons: true,
+	}
+}
+
+// WithModules initializes the injector with the given modules
+func WithModules(modules ...Module) Option {
+	return func(o *options) {
+		o.modules = append(o.modules, modules...)
+	}
+}
+
+// WithLogger sets the logger used for tracing output, slog.Default() is used if not set
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithCircularTracing activates dingo's trace feature to find circular dependencies
+// this is super expensive (memory wise), so it should only be used for debugging purposes
+func WithCircularTracing() Option {
+	return func(o *options) {
+		o.circularTracing = true
+	}
+}
+
+// WithInjectionTracing logs every just in time created type and every set field
+func WithInjectionTracing() Option {
+	return func(o *options) {
+		o.injectionTracing = true
+	}
+}
+
+// WithStrictMode disables the just in time creation of concrete types without binding.
+// Requesting an unbound named type fails, unless the injection is optional or the type is allowed by a filter.
+func WithStrictMode(allow ...JustInTimeFilter) Option {
+	return func(o *options) {
+		o.strict = true
+		o.strictAllow = append(o.strictAllow, allow...)
+	}
+}
+
+// WithEagerSingletons enables or disables building of eager singletons during InitModules, default is enabled
+func WithEagerSingletons(build bool) Option {
+	return func(o *options) {
+		o.buildEagerSingletons = build
+	}
+}
+
+// WithObserver registers an Observer, see Injector.AddObserver
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, observer)
+	}
+}
+
+// WithMultibindingDeduplication injects equal multi bindings only once for all types, like types bound with BindSet.
+// Bindings marked with PermitDuplicates are kept.
+func WithMultibindingDeduplication() Option {
+	return func(o *options) {
+		o.deduplicateMultibindings = true
+	}
+}
+
+func (injector *Injector) logger() *slog.Logger {
+	if injector.options.logger != nil {
+		return injector.options.logger
+	}
+	return slog.Default()
+}
+
+func (injector *Injector) circularTracing() bool {
+	return injector.options.circularTracing
+}
+
+// circularTrace returns the initial trace for a resolution, nil if circular tracing is disabled
+func (injector *Injector) circularTrace() []circularTraceEntry {
+	if injector.circularTracing() {
+		return make([]circularTraceEntry, 0)
+	}
+	return nil
+}
+
+func (injector *Injector) injectionTracing() bool {
+	return injector.options.injectionTracing
+}
@@ -0,0 +1,133 @@
+package dingo
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	optionsStruct struct {
+		Dep *optionsDep `inject:""`
+	}
+
+	optionsDep struct {
+		Value string `inject:"value,optional"`
+	}
+
+	optionsEager struct{}
+
+	optionsOptional struct {
+		Dep *optionsDep `inject:",optional"`
+	}
+)
+
+func TestNewInjectorWithOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("modules", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithModules(new(tryModuleOk)))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(string))
+		require.NoError(t, err)
+		assert.Equal(t, "test", i)
+	})
+
+	t.Run("injection tracing with logger", func(t *testing.T) {
+		t.Parallel()
+
+		buf := new(bytes.Buffer)
+		injector, err := NewInjectorWithOptions(WithInjectionTracing(), WithLogger(slog.New(slog.NewTextHandler(buf, nil))))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(optionsStruct))
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), `INJECTING: flamingo.me/dingo#optionsDep`)
+		assert.Contains(t, buf.String(), `SETTING FIELD: Dep of type`)
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithStrictMode())
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(optionsStruct))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.optionsStruct")
+
+		i, err := injector.GetInstance(new(optionsOptional))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.optionsOptional")
+		assert.Nil(t, i)
+
+		injector.Bind(new(optionsStruct))
+		_, err = injector.GetInstance(new(optionsStruct))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.optionsDep")
+
+		injector.Bind(new(optionsDep))
+		injector.Bind(new(string)).AnnotatedWith("value").ToInstance("value")
+		i, err = injector.GetInstance(new(optionsStruct))
+		require.NoError(t, err)
+		assert.Equal(t, "value", i.(*optionsStruct).Dep.Value)
+
+		child, err := injector.Child()
+		require.NoError(t, err)
+		_, err = child.GetInstance(new(optionsOptional))
+		assert.ErrorContains(t, err, "strict mode", "child injectors inherit the options")
+	})
+
+	t.Run("eager singletons", func(t *testing.T) {
+		t.Parallel()
+
+		built := 0
+		module := ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(optionsEager)).ToProvider(func() *optionsEager {
+				built++
+				return new(optionsEager)
+			}).AsEagerSingleton().In(ChildSingleton)
+		})
+
+		_, err := NewInjectorWithOptions(WithEagerSingletons(false), WithModules(module))
+		require.NoError(t, err)
+		assert.Equal(t, 0, built)
+
+		injector, err := NewInjectorWithOptions(WithModules(module))
+		require.NoError(t, err)
+		assert.Equal(t, 1, built)
+
+		_, err = injector.Child()
+		require.NoError(t, err)
+		assert.Equal(t, 1, built)
+
+		parent, err := NewInjectorWithOptions(WithEagerSingletons(false))
+		require.NoError(t, err)
+		child, err := parent.Child()
+		require.NoError(t, err)
+		require.NoError(t, child.InitModules(module))
+		assert.Equal(t, 1, built, "child injectors inherit the setting")
+
+		child, err = parent.Child()
+		require.NoError(t, err)
+		child.SetBuildEagerSingletons(true)
+		require.NoError(t, child.InitModules(module))
+		assert.Equal(t, 2, built)
+	})
+
+	t.Run("observer", func(t *testing.T) {
+		t.Parallel()
+
+		observer := new(recordingObserver)
+		injector, err := NewInjectorWithOptions(WithObserver(observer))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(observerDep))
+		require.NoError(t, err)
+		assert.Len(t, observer.events, 2)
+	})
+}
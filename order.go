@@ -0,0 +1,162 @@
+package dingo
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// WithKey names a multi binding, so other multi bindings can be ordered relative to it with Before and After
+func (b *Binding) WithKey(key string) *Binding {
+	b.key = key
+	return b
+}
+
+// WithPriority orders a multi binding: bindings with a higher priority come first in the injected slice.
+// Bindings with the same priority, 0 by default, keep the order of registration, parent bindings first.
+func (b *Binding) WithPriority(priority int) *Binding {
+	b.priority = priority
+	return b
+}
+
+// Before places a multi binding before the multi bindings with the keys, unknown keys are ignored
+func (b *Binding) Before(keys ...string) *Binding {
+	b.before = append(b.before, keys...)
+	return b
+}
+
+// After places a multi binding after the multi bindings with the keys, unknown keys are ignored
+func (b *Binding) After(keys ...string) *Binding {
+	b.after = append(b.after, keys...)
+	return b
+}
+
+// ordered checks if the binding has ordering constraints
+func (b *Binding) ordered() bool {
+	return b.priority != 0 || len(b.before) > 0 || len(b.after) > 0
+}
+
+// orderMultibindings sorts the bindings by their Before and After constraints, and by priority and registration
+// order where the constraints allow it
+func orderMultibindings(bindings []*Binding) ([]*Binding, error) {
+	if !slices.ContainsFunc(bindings, (*Binding).ordered) {
+		return bindings, nil
+	}
+
+	keys := make(map[string][]int)
+	for i, binding := range bindings {
+		if binding.key != "" {
+			keys[binding.key] = append(keys[binding.key], i)
+		}
+	}
+
+	// successors[i] must come after i
+	successors := make([][]int, len(bindings))
+	incoming := make([]int, len(bindings))
+	edge := func(from, to int) {
+		successors[from] = append(successors[from], to)
+		incoming[to]++
+	}
+	for i, binding := range bindings {
+		for _, key := range binding.before {
+			for _, j := range keys[key] {
+				edge(i, j)
+			}
+		}
+		for _, key := range binding.after {
+			for _, j := range keys[key] {
+				edge(j, i)
+			}
+		}
+	}
+
+	less := func(a, b int) int {
+		if bindings[a].priority != bindings[b].priority {
+			return cmp.Compare(bindings[b].priority, bindings[a].priority)
+		}
+		return cmp.Compare(a, b)
+	}
+
+	var ready []int
+	for i := range bindings {
+		if incoming[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]*Binding, 0, len(bindings))
+	for len(ready) > 0 {
+		slices.SortFunc(ready, less)
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, bindings[next])
+
+		for _, j := range successors[next] {
+			if incoming[j]--; incoming[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	if len(ordered) < len(bindings) {
+		return bindings, fmt.Errorf("cyclic Before/After constraints between %s", strings.Join(cycleKeys(bindings, successors, incoming), ", "))
+	}
+
+	return ordered, nil
+}
+
+// cycleKeys returns the keys of the bindings on a cycle: of the bindings left by the topological sort,
+// those only waiting for a cycle are removed
+func cycleKeys(bindings []*Binding, successors [][]int, incoming []int) []string {
+	remaining := make(map[int]bool)
+	for i := range bindings {
+		if incoming[i] > 0 {
+			remaining[i] = true
+		}
+	}
+
+	for removed := true; removed; {
+		removed = false
+		for i := range remaining {
+			if !slices.ContainsFunc(successors[i], func(j int) bool { return remaining[j] }) {
+				delete(remaining, i)
+				removed = true
+			}
+		}
+	}
+
+	var keys []string
+	for i, binding := range bindings {
+		if remaining[i] {
+			keys = append(keys, fmt.Sprintf("%q", binding.key))
+		}
+	}
+	return keys
+}
+
+// checkMultibindingOrder reports cyclic ordering constraints of the multi bindings, including those of the parents
+func (injector *Injector) checkMultibindingOrder() error {
+	for t, bindings := range injector.multibindings {
+		checked := make(map[string]bool)
+		for _, binding := range bindings {
+			if checked[binding.annotatedWith] {
+				continue
+			}
+			checked[binding.annotatedWith] = true
+
+			if _, err := orderMultibindings(injector.collectMultibindings(t, binding.annotatedWith)); err != nil {
+				return fmt.Errorf("multibinding order of %s (annotated with %q): %w", t, binding.annotatedWith, err)
+			}
+		}
+	}
+	return nil
+}
+
+// joinMultibindings returns the ordered multi bindings of the injector and its parents
+func (injector *Injector) joinMultibindings(t reflect.Type, annotation string) []*Binding {
+	// cyclic constraints are reported by InitModules, the registration order is kept then
+	bindings, _ := orderMultibindings(injector.collectMultibindings(t, annotation))
+	return bindings
+}
@@ -0,0 +1,82 @@
+package dingo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultibindingOrder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("priority, before and after", func(t *testing.T) {
+		t.Parallel()
+
+		parent, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("recover").WithKey("recover").WithPriority(100)
+			injector.BindMulti(new(string)).ToInstance("handler").WithKey("handler")
+		}))
+		require.NoError(t, err)
+
+		injector, err := parent.Child()
+		require.NoError(t, err)
+		require.NoError(t, injector.InitModules(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("auth").WithKey("auth").Before("handler")
+			injector.BindMulti(new(string)).ToInstance("session").WithKey("session").Before("auth").After("recover", "unknown")
+			injector.BindMulti(new(string)).ToInstance("metrics").WithPriority(-1)
+			injector.BindMulti(new(string)).AnnotatedWith("other").ToInstance("other")
+		})))
+
+		i, err := injector.GetInstance(new([]string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"recover", "session", "auth", "handler", "metrics"}, i)
+
+		i, err = parent.GetInstance(new([]string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"recover", "handler"}, i)
+	})
+
+	t.Run("registration order without constraints", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("a")
+			injector.BindMulti(new(string)).ToInstance("b").WithKey("b")
+			injector.BindMulti(new(string)).ToInstance("c")
+		}))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new([]string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, i)
+	})
+
+	t.Run("extreme priorities", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("min").WithPriority(math.MinInt)
+			injector.BindMulti(new(string)).ToInstance("default")
+			injector.BindMulti(new(string)).ToInstance("max").WithPriority(math.MaxInt)
+		}))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new([]string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"max", "default", "min"}, i)
+	})
+
+	t.Run("cycles are reported at init", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("a").WithKey("a").Before("b")
+			injector.BindMulti(new(string)).ToInstance("b").WithKey("b").Before("c")
+			injector.BindMulti(new(string)).ToInstance("c").WithKey("c").Before("a")
+			injector.BindMulti(new(string)).ToInstance("d").WithKey("d").After("a")
+		}))
+		assert.EqualError(t, err, `multibinding order of string (annotated with ""): cyclic Before/After constraints between "a", "b", "c"`)
+	})
+}
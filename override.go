@@ -0,0 +1,151 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+type (
+	// elementOverride replaces, or removes if there is no binding, a map binding or a keyed multi binding.
+	// Like overrides they are evaluated lazy, after all modules are configured.
+	elementOverride struct {
+		typ           reflect.Type
+		key           interface{}
+		annotatedWith string // annotation of a multi binding, map bindings are identified by key only
+		multi         bool
+		binding       *Binding
+	}
+
+	// mapDuplicate records a map binding registered for an already bound key
+	mapDuplicate struct {
+		key            interface{}
+		known, binding *Binding
+	}
+)
+
+// OverrideMap replaces the map binding of the type with the key, the replacement keeps the annotation of the replaced
+// binding
+func (injector *Injector) OverrideMap(what interface{}, key interface{}) *Binding {
+	return injector.overrideElement(what, key, "", false, true)
+}
+
+// RemoveMap removes the map binding of the type with the key
+func (injector *Injector) RemoveMap(what interface{}, key interface{}) {
+	injector.overrideElement(what, key, "", false, false)
+}
+
+// OverrideMulti replaces the multi binding of the type with the annotation which is named with WithKey.
+// The replacement keeps the position, the key and the annotation of the replaced binding, and its priority and
+// Before and After constraints unless the replacement is ordered itself.
+func (injector *Injector) OverrideMulti(what interface{}, annotatedWith string, key string) *Binding {
+	return injector.overrideElement(what, key, annotatedWith, true, true).WithKey(key).AnnotatedWith(annotatedWith)
+}
+
+// RemoveMulti removes the multi binding of the type with the annotation which is named with WithKey
+func (injector *Injector) RemoveMulti(what interface{}, annotatedWith string, key string) {
+	injector.overrideElement(what, key, annotatedWith, true, false)
+}
+
+func (injector *Injector) overrideElement(what interface{}, key interface{}, annotatedWith string, multi, replace bool) *Binding {
+	bindtype, err := bindType(what)
+	if err == nil {
+		err = checkMapKey(key)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	override := &elementOverride{typ: bindtype, key: key, annotatedWith: annotatedWith, multi: multi}
+	if replace {
+		override.binding = &Binding{typeof: bindtype}
+	}
+	injector.elementOverrides = append(injector.elementOverrides, override)
+
+	return override.binding
+}
+
+// applyElementOverrides evaluates the scheduled map and multi binding overrides and reports duplicated map keys
+// which are not overridden
+func (injector *Injector) applyElementOverrides() error {
+	overrides, duplicates := injector.elementOverrides, injector.mapDuplicates
+	injector.elementOverrides, injector.mapDuplicates = nil, nil
+
+	type element struct {
+		typ reflect.Type
+		key interface{}
+	}
+	overridden := make(map[element]bool)
+
+	for _, override := range overrides {
+		kind, action := "map", "override"
+		if override.multi {
+			kind = "multi"
+		}
+		if override.binding == nil {
+			action = "remove"
+		}
+
+		var ok bool
+		if override.multi {
+			ok = injector.overrideMulti(override)
+		} else {
+			ok = injector.overrideMap(override)
+			overridden[element{typ: override.typ, key: override.key}] = true
+		}
+		if !ok && override.annotatedWith != "" {
+			return fmt.Errorf("cannot %s unknown %s binding %q annotated with %q with key %s", action, kind, override.typ.String(), override.annotatedWith, formatMapKey(override.key))
+		}
+		if !ok {
+			return fmt.Errorf("cannot %s unknown %s binding %q with key %s", action, kind, override.typ.String(), formatMapKey(override.key))
+		}
+	}
+
+	for _, duplicate := range duplicates {
+		if overridden[element{typ: duplicate.known.typeof, key: duplicate.key}] || duplicate.known.equal(duplicate.binding) {
+			continue
+		}
+		return fmt.Errorf("already known map binding for %q with key %s, use OverrideMap to replace it", duplicate.known.typeof, formatMapKey(duplicate.key))
+	}
+
+	return nil
+}
+
+func (injector *Injector) overrideMap(override *elementOverride) bool {
+	bindings := injector.mapbindings[override.typ]
+	replaced, ok := bindings[override.key]
+	if !ok {
+		return false
+	}
+	if override.binding == nil {
+		delete(bindings, override.key)
+	} else {
+		override.binding.annotatedWith = replaced.annotatedWith
+		bindings[override.key] = override.binding
+	}
+	return true
+}
+
+// overrideMulti replaces the first multi binding with the key and annotation and removes all others
+func (injector *Injector) overrideMulti(override *elementOverride) bool {
+	matches := func(binding *Binding) bool {
+		return binding.key == override.key && binding.annotatedWith == override.annotatedWith
+	}
+
+	bindings := injector.multibindings[override.typ]
+	i := slices.IndexFunc(bindings, matches)
+	if i < 0 {
+		return false
+	}
+	if override.binding != nil {
+		if !override.binding.ordered() {
+			override.binding.priority = bindings[i].priority
+			override.binding.before = bindings[i].before
+			override.binding.after = bindings[i].after
+		}
+		bindings[i] = override.binding
+		i++
+	}
+	injector.multibindings[override.typ] = append(bindings[:i], slices.DeleteFunc(bindings[i:], matches)...)
+	return true
+}
@@ -0,0 +1,161 @@
+package dingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementOverrides(t *testing.T) {
+	t.Parallel()
+
+	t.Run("map bindings", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(
+			ModuleFunc(func(injector *Injector) {
+				injector.BindMap(new(string), "a").ToInstance("a")
+				injector.BindMap(new(string), "b").ToInstance("b")
+				injector.BindMap(new(string), "c").ToInstance("c")
+			}),
+			ModuleFunc(func(injector *Injector) {
+				injector.OverrideMap(new(string), "a").ToInstance("a2")
+				injector.RemoveMap(new(string), "b")
+			}),
+		)
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(map[string]string))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "a2", "c": "c"}, i)
+	})
+
+	t.Run("keyed multi bindings", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(
+			ModuleFunc(func(injector *Injector) {
+				injector.BindMulti(new(string)).ToInstance("a").WithKey("a")
+				injector.BindMulti(new(string)).ToInstance("b").WithKey("b")
+				injector.BindMulti(new(string)).ToInstance("c").WithKey("c").Before("a")
+				injector.BindMulti(new(string)).ToInstance("d")
+			}),
+			ModuleFunc(func(injector *Injector) {
+				injector.OverrideMulti(new(string), "", "a").ToInstance("a2")
+				injector.RemoveMulti(new(string), "", "b")
+			}),
+		)
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new([]string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c", "a2", "d"}, i)
+	})
+
+	t.Run("annotated map bindings", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(
+			ModuleFunc(func(injector *Injector) {
+				injector.BindMap(new(string), "a").AnnotatedWith("x").ToInstance("a")
+				injector.BindMap(new(string), "b").AnnotatedWith("x").ToInstance("b")
+				injector.BindMap(new(string), "c").ToInstance("c")
+			}),
+			ModuleFunc(func(injector *Injector) {
+				injector.OverrideMap(new(string), "a").ToInstance("a2")
+				injector.RemoveMap(new(string), "b")
+			}),
+		)
+		require.NoError(t, err)
+
+		var dep struct {
+			Annotated map[string]string `inject:"x"`
+			Plain     map[string]string `inject:""`
+		}
+		require.NoError(t, injector.RequestInjection(&dep))
+		assert.Equal(t, map[string]string{"a": "a2"}, dep.Annotated)
+		assert.Equal(t, map[string]string{"c": "c"}, dep.Plain)
+	})
+
+	t.Run("annotated keyed multi bindings", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(
+			ModuleFunc(func(injector *Injector) {
+				injector.BindMulti(new(string)).AnnotatedWith("x").ToInstance("x-a").WithKey("a")
+				injector.BindMulti(new(string)).AnnotatedWith("x").ToInstance("x-d").WithKey("d")
+				injector.BindMulti(new(string)).AnnotatedWith("x").ToInstance("x-c").WithKey("c").Before("d")
+				injector.BindMulti(new(string)).AnnotatedWith("x").ToInstance("x-b").WithKey("b").WithPriority(10)
+				injector.BindMulti(new(string)).ToInstance("a").WithKey("a")
+				injector.BindMulti(new(string)).ToInstance("b").WithKey("b")
+			}),
+			ModuleFunc(func(injector *Injector) {
+				injector.OverrideMulti(new(string), "x", "b").ToInstance("x-b2")
+				injector.OverrideMulti(new(string), "x", "c").ToInstance("x-c2")
+				injector.RemoveMulti(new(string), "x", "a")
+				injector.OverrideMulti(new(string), "", "a").ToInstance("a2").WithPriority(-1)
+			}),
+		)
+		require.NoError(t, err)
+
+		var dep struct {
+			Annotated []string `inject:"x"`
+			Plain     []string `inject:""`
+		}
+		require.NoError(t, injector.RequestInjection(&dep))
+		assert.Equal(t, []string{"x-b2", "x-c2", "x-d"}, dep.Annotated, "the replacements keep priority and constraints")
+		assert.Equal(t, []string{"b", "a2"}, dep.Plain, "the replacement is ordered by its own priority")
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMap(new(string), "a").ToInstance("a")
+			injector.OverrideMap(new(string), "b").ToInstance("b")
+		}))
+		assert.EqualError(t, err, `cannot override unknown map binding "string" with key "b"`)
+
+		_, err = NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMap(new(string), "1").ToInstance("a")
+			injector.RemoveMap(new(string), mapBindKind(1))
+		}))
+		assert.EqualError(t, err, `cannot remove unknown map binding "string" with key 1`)
+
+		_, err = NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("a")
+			injector.RemoveMulti(new(string), "", "a")
+		}))
+		assert.EqualError(t, err, `cannot remove unknown multi binding "string" with key "a"`)
+
+		_, err = NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(string)).ToInstance("a").WithKey("a")
+			injector.OverrideMulti(new(string), "other", "a").ToInstance("a2")
+		}))
+		assert.EqualError(t, err, `cannot override unknown multi binding "string" annotated with "other" with key "a"`)
+	})
+
+	t.Run("duplicate map keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMap(new(string), "a").ToInstance("a")
+			injector.BindMap(new(string), "a").ToInstance("b")
+		}))
+		assert.EqualError(t, err, `already known map binding for "string" with key "a", use OverrideMap to replace it`)
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMap(new(string), "a").ToInstance("a")
+			injector.BindMap(new(string), "a").ToInstance("a")
+			injector.BindMap(new(string), "b").ToInstance("b")
+			injector.BindMap(new(string), "b").ToInstance("b2")
+			injector.OverrideMap(new(string), "b").ToInstance("b3")
+		}))
+		require.NoError(t, err)
+
+		i, err := injector.GetInstance(new(map[string]string))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "a", "b": "b3"}, i)
+	})
+}
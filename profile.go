@@ -0,0 +1,91 @@
+package dingo
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"sync"
+)
+
+const (
+	profileProvider       = "provider"
+	profileInject         = "inject"
+	profileEagerSingleton = "eager-singleton"
+)
+
+// WithProfilerLabels wraps provider calls, Inject calls and the construction of eager singletons
+// in runtime/pprof labels and runtime/trace regions named after the resolved type and annotation.
+//
+// The labels "dingo" (provider, inject or eager-singleton), "dingo.type" and "dingo.annotation" attribute the time
+// spent in provider functions and Inject methods to their bindings. Nested calls replace the labels while they run,
+// afterwards the labels of the outer call are restored; trace regions nest properly.
+// After the outermost call the goroutine labels are restored to the labels of the WithProfilerContext context.
+func WithProfilerLabels() Option {
+	return func(o *options) {
+		o.profilerLabels = true
+	}
+}
+
+// WithProfilerContext sets the context carrying the pprof labels of the goroutines using the injector.
+// The labels of a goroutine can not be read, so WithProfilerLabels adds its labels to the labels of ctx and resets
+// the goroutine to the labels of ctx after each labeled call. Without it the goroutine has no labels afterwards.
+func WithProfilerContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.profilerContext = ctx
+	}
+}
+
+// profile runs fn, if enabled within profiler labels and a trace region
+func (injector *Injector) profile(kind string, t reflect.Type, annotation string, fn func()) {
+	if !injector.options.profilerLabels {
+		fn()
+		return
+	}
+
+	name := "dingo " + kind + " " + t.String()
+	if annotation != "" {
+		name += " (" + annotation + ")"
+	}
+
+	// nested calls are reached through provider functions and Inject methods, so the labeled context of the outer
+	// call is carried per goroutine
+	id := goroutineID()
+	outer, nested := profileContexts.Load(id)
+	if !nested {
+		outer = injector.options.profilerContext
+		if outer == nil {
+			outer = context.Background()
+		}
+	}
+
+	pprof.Do(outer.(context.Context), pprof.Labels("dingo", kind, "dingo.type", t.String(), "dingo.annotation", annotation), func(ctx context.Context) {
+		profileContexts.Store(id, ctx)
+		defer func() {
+			if nested {
+				profileContexts.Store(id, outer)
+			} else {
+				profileContexts.Delete(id)
+			}
+		}()
+		trace.WithRegion(ctx, name, fn)
+	})
+}
+
+// profileContexts holds the labeled context of the running profiled call by goroutine id
+var profileContexts sync.Map
+
+// goroutineID parses the id of the current goroutine from its stack trace header "goroutine 42 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,120 @@
+package dingo
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type profileEager struct {
+	labels string
+}
+
+func (p *profileEager) Inject() {
+	p.labels = goroutineLabels()
+}
+
+// goroutineLabels returns the goroutine profile, which lists the labels of all goroutines
+func goroutineLabels() string {
+	buf := new(bytes.Buffer)
+	_ = pprof.Lookup("goroutine").WriteTo(buf, 1)
+	return buf.String()
+}
+
+func TestWithProfilerLabels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, trace.Start(buf))
+
+	var providerLabels string
+
+	injector, err := NewInjectorWithOptions(WithProfilerLabels(), WithModules(ModuleFunc(func(injector *Injector) {
+		injector.Bind(new(string)).AnnotatedWith("labeled").ToProvider(func() string {
+			providerLabels = goroutineLabels()
+			return "labeled"
+		})
+		injector.Bind(new(profileEager)).AsEagerSingleton()
+	})))
+	require.NoError(t, err)
+
+	_, err = injector.GetAnnotatedInstance(new(string), "labeled")
+	require.NoError(t, err)
+
+	trace.Stop()
+	assert.NotEmpty(t, buf.Bytes())
+
+	assert.Contains(t, providerLabels, `"dingo":"provider"`)
+	assert.Contains(t, providerLabels, `"dingo.type":"string"`)
+	assert.Contains(t, providerLabels, `"dingo.annotation":"labeled"`)
+
+	i, err := injector.GetInstance(new(profileEager))
+	require.NoError(t, err)
+	assert.Contains(t, i.(*profileEager).labels, `"dingo":"inject"`)
+	assert.Contains(t, i.(*profileEager).labels, `"dingo.type":"dingo.profileEager"`)
+
+	assert.NotContains(t, goroutineLabels(), `"dingo":`, "labels are removed after the calls")
+}
+
+type (
+	profileOuter struct {
+		labels string
+	}
+
+	profileInner struct{}
+
+	profileInnerProvider func() *profileInner
+)
+
+func TestWithProfilerLabelsNested(t *testing.T) {
+	var innerLabels string
+
+	injector, err := NewInjectorWithOptions(WithProfilerLabels(), WithModules(ModuleFunc(func(injector *Injector) {
+		injector.Bind(new(profileInner)).ToProvider(func() *profileInner {
+			innerLabels = goroutineLabels()
+			return new(profileInner)
+		})
+		injector.Bind(new(profileOuter)).ToProvider(func(inner profileInnerProvider) *profileOuter {
+			inner()
+			return &profileOuter{labels: goroutineLabels()}
+		})
+	})))
+	require.NoError(t, err)
+
+	i, err := injector.GetInstance(new(profileOuter))
+	require.NoError(t, err)
+
+	assert.Contains(t, innerLabels, `"dingo.type":"dingo.profileInner"`)
+	assert.Contains(t, i.(*profileOuter).labels, `"dingo.type":"dingo.profileOuter"`, "the outer labels are restored")
+	assert.NotContains(t, i.(*profileOuter).labels, `"dingo.type":"dingo.profileInner"`)
+	assert.NotContains(t, goroutineLabels(), `"dingo":`)
+}
+
+func TestWithProfilerContext(t *testing.T) {
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("app", "request"))
+	pprof.SetGoroutineLabels(ctx)
+	defer pprof.SetGoroutineLabels(context.Background())
+
+	var providerLabels string
+
+	injector, err := NewInjectorWithOptions(WithProfilerLabels(), WithProfilerContext(ctx), WithModules(ModuleFunc(func(injector *Injector) {
+		injector.Bind(new(string)).ToProvider(func() string {
+			providerLabels = goroutineLabels()
+			return "labeled"
+		})
+	})))
+	require.NoError(t, err)
+
+	_, err = injector.GetInstance(new(string))
+	require.NoError(t, err)
+
+	assert.Contains(t, providerLabels, `"app":"request"`)
+	assert.Contains(t, providerLabels, `"dingo":"provider"`)
+
+	labels := goroutineLabels()
+	assert.Contains(t, labels, `"app":"request"`, "labels of the context are restored")
+	assert.NotContains(t, labels, `"dingo":`)
+}
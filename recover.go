@@ -0,0 +1,67 @@
+package dingo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime/debug"
+)
+
+// WithPanicRecovery recovers panics of providers and Inject methods during resolution,
+// and returns them as *ProviderError with the stack trace of the panic
+func WithPanicRecovery() Option {
+	return func(o *options) {
+		o.recoverPanics = true
+	}
+}
+
+// WithSafeProviders lets injected providers, which can not return an error, log resolution errors and return
+// the zero value instead of panicking
+func WithSafeProviders() Option {
+	return func(o *options) {
+		o.safeProviders = true
+	}
+}
+
+// recoverPanic calls fnc, and converts a panic into a *ProviderError if panic recovery is enabled
+func (injector *Injector) recoverPanic(t reflect.Type, annotation string, provider string, fnc func()) (err error) {
+	if !injector.options.recoverPanics {
+		fnc()
+		return nil
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		// circular dependencies detected by circular tracing are returned as they are
+		var cycle *CycleError
+		if rerr, ok := r.(error); ok && errors.As(rerr, &cycle) {
+			err = cycle
+			return
+		}
+
+		perr, ok := r.(error)
+		if !ok {
+			perr = fmt.Errorf("%v", r)
+		}
+		err = &ProviderError{Type: t, Annotation: annotation, Provider: provider, Err: perr, Panic: r, Stack: debug.Stack()}
+	}()
+
+	fnc()
+	return nil
+}
+
+// providerFailed panics with the resolution error of an injected provider which can not return it,
+// with safe providers the error is logged and the zero value is returned instead
+func (injector *Injector) providerFailed(t reflect.Type, err error) []reflect.Value {
+	if !injector.options.safeProviders {
+		panic(fmt.Errorf("%q: %w", t, err))
+	}
+
+	injector.logger().Error("dingo: provider failed, returning zero value", slog.String("provider", t.String()), slog.Any("error", err))
+	return []reflect.Value{reflect.Zero(t.Out(0))}
+}
@@ -0,0 +1,103 @@
+package dingo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	recoverPanicking struct{}
+
+	recoverInjectPanicking struct{}
+
+	recoverRoot struct {
+		Panicking *recoverInjectPanicking `inject:""`
+	}
+
+	recoverIface interface{}
+
+	recoverIfaceProvider func() recoverIface
+)
+
+var errRecoverPanic = errors.New("panic in provider")
+
+func (*recoverInjectPanicking) Inject() {
+	panic("panic in Inject")
+}
+
+func TestWithPanicRecovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("provider", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithPanicRecovery(), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(recoverPanicking)).ToProvider(func() *recoverPanicking {
+				panic(errRecoverPanic)
+			})
+		})))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(recoverPanicking))
+
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.ErrorIs(t, err, errRecoverPanic)
+		assert.Equal(t, errRecoverPanic, providerErr.Panic)
+		assert.Contains(t, string(providerErr.Stack), "TestWithPanicRecovery")
+		assert.Contains(t, err.Error(), "panicked: panic in provider")
+	})
+
+	t.Run("Inject method with path", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithPanicRecovery())
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(recoverRoot))
+
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, "panic in Inject", providerErr.Panic)
+		assert.Equal(t, "*dingo.recoverInjectPanicking.Inject", providerErr.Provider)
+		require.Len(t, providerErr.Path, 1)
+		assert.Equal(t, "Panicking", providerErr.Path[0].Field)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector()
+		require.NoError(t, err)
+
+		assert.PanicsWithValue(t, "panic in Inject", func() {
+			_, _ = injector.GetInstance(new(recoverRoot))
+		})
+	})
+}
+
+func TestWithSafeProviders(t *testing.T) {
+	t.Parallel()
+
+	injector, err := NewInjectorWithOptions(WithSafeProviders())
+	require.NoError(t, err)
+
+	i, err := injector.GetInstance(new(recoverIfaceProvider))
+	require.NoError(t, err)
+
+	assert.NotPanics(t, func() {
+		assert.Nil(t, i.(recoverIfaceProvider)())
+	})
+
+	injector, err = NewInjector()
+	require.NoError(t, err)
+
+	i, err = injector.GetInstance(new(recoverIfaceProvider))
+	require.NoError(t, err)
+	assert.Panics(t, func() {
+		i.(recoverIfaceProvider)()
+	})
+}
@@ -22,6 +22,7 @@ type (
 		mu           sync.Mutex                   // lock guarding instaceLocks
 		instanceLock map[identifier]*sync.RWMutex // lock guarding instances
 		instances    sync.Map
+		errs         sync.Map // errors of failed resolutions, returned again for later requests
 	}
 
 	// ChildSingletonScope manages child-specific singleton
@@ -59,6 +60,9 @@ func (s *SingletonScope) ResolveType(t reflect.Type, annotation string, unscoped
 		l.RLock()
 		defer l.RUnlock()
 
+		if err, ok := s.errs.Load(ident); ok {
+			return reflect.Value{}, err.(error)
+		}
 		instance, _ := s.instances.Load(ident)
 		return instance.(reflect.Value), nil
 	}
@@ -69,6 +73,9 @@ func (s *SingletonScope) ResolveType(t reflect.Type, annotation string, unscoped
 	s.mu.Unlock()
 
 	instance, err := unscoped(t, annotation, false)
+	if err != nil {
+		s.errs.Store(ident, err)
+	}
 	s.instances.Store(ident, instance)
 
 	defer l.Unlock()
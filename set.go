@@ -0,0 +1,92 @@
+package dingo
+
+import (
+	"reflect"
+	"slices"
+)
+
+// BindSet binds a multi binding with set semantics: equal multi bindings of the type are injected only once,
+// no matter how often, by which module or in which injector of the chain they are bound
+func (injector *Injector) BindSet(what interface{}) *Binding {
+	binding, err := injector.TryBindSet(what)
+	if err != nil {
+		panic(err)
+	}
+	return binding
+}
+
+// TryBindSet is BindSet, returning an ErrInvalidBinding error instead of panicking
+func (injector *Injector) TryBindSet(what interface{}) (*Binding, error) {
+	binding, err := injector.TryBindMulti(what)
+	if err != nil {
+		return nil, err
+	}
+	injector.sets[binding.typeof] = true
+	return binding, nil
+}
+
+// PermitDuplicates keeps a multi binding even if an equal multi binding is already bound,
+// for types bound with BindSet or injectors deduplicating all multi bindings
+func (b *Binding) PermitDuplicates() *Binding {
+	b.permitDuplicates = true
+	return b
+}
+
+// isSet checks if the type is bound with BindSet in the injector or one of its parents
+func (injector *Injector) isSet(t reflect.Type) bool {
+	for current := injector; current != nil; current = current.parent {
+		if current.sets[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// deduplicateMultibindings drops the multi bindings of the injector equal to an earlier one of the injector or its
+// parents, keeping the first registration. It runs once when the modules are initialized, so multi bindings added
+// later are not deduplicated.
+func (injector *Injector) deduplicateMultibindings() {
+	for t, bindings := range injector.multibindings {
+		if !injector.options.deduplicateMultibindings && !injector.isSet(t) {
+			continue
+		}
+
+		var known []*Binding
+		for current := injector.parent; current != nil; current = current.parent {
+			known = append(known, current.multibindings[t]...)
+		}
+
+		unique := make([]*Binding, 0, len(bindings))
+		for _, binding := range bindings {
+			if !binding.permitDuplicates && slices.ContainsFunc(known, binding.sameElement) {
+				continue
+			}
+			known = append(known, binding)
+			unique = append(unique, binding)
+		}
+		injector.multibindings[t] = unique
+	}
+}
+
+// sameElement checks if two multi bindings inject the same element: the same target, provider function, instance,
+// annotation and scope. Keys and ordering constraints are not compared.
+func (b *Binding) sameElement(other *Binding) bool {
+	if b.to != other.to || b.annotatedWith != other.annotatedWith || b.scope != other.scope {
+		return false
+	}
+
+	if (b.provider == nil) != (other.provider == nil) || (b.instance == nil) != (other.instance == nil) {
+		return false
+	}
+	// reflect.DeepEqual of the reflect.Values compares the function values, not only their code
+	if b.provider != nil && !reflect.DeepEqual(b.provider.fnc, other.provider.fnc) {
+		return false
+	}
+	if b.instance != nil {
+		if b.instance.itype != other.instance.itype || !b.instance.ivalue.Comparable() {
+			return false
+		}
+		return b.instance.ivalue.Equal(other.instance.ivalue)
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package dingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	setListener interface {
+		Name() string
+	}
+
+	setListenerA struct{}
+
+	setListenerB struct{}
+)
+
+func (setListenerA) Name() string { return "a" }
+
+func (setListenerB) Name() string { return "b" }
+
+func newSetListener() setListener { return setListenerB{} }
+
+func setListenerNames(t *testing.T, injector *Injector) []string {
+	t.Helper()
+
+	i, err := injector.GetInstance(new([]setListener))
+	require.NoError(t, err)
+
+	var names []string
+	for _, listener := range i.([]setListener) {
+		names = append(names, listener.Name())
+	}
+	return names
+}
+
+func TestBindSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal bindings are injected once", func(t *testing.T) {
+		t.Parallel()
+
+		listeners := func(injector *Injector) {
+			injector.BindSet(new(setListener)).To(setListenerA{})
+			injector.BindSet(new(setListener)).ToProvider(newSetListener)
+		}
+		parent, err := NewInjector(ModuleFunc(listeners), ModuleFunc(listeners), ModuleFunc(func(injector *Injector) {
+			injector.BindSet(new(setListener)).ToInstance(setListenerA{})
+			injector.BindSet(new(setListener)).ToInstance(setListenerA{})
+			injector.BindSet(new(setListener)).To(setListenerA{}).PermitDuplicates()
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "a", "a"}, setListenerNames(t, parent))
+
+		child, err := parent.Child()
+		require.NoError(t, err)
+		require.NoError(t, child.InitModules(ModuleFunc(listeners)))
+		assert.Equal(t, []string{"a", "b", "a", "a"}, setListenerNames(t, child))
+	})
+
+	t.Run("multi bindings keep duplicates by default", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(setListener)).To(setListenerA{})
+			injector.BindMulti(new(setListener)).To(setListenerA{})
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "a"}, setListenerNames(t, injector))
+	})
+
+	t.Run("deduplication option", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithMultibindingDeduplication(), WithModules(ModuleFunc(func(injector *Injector) {
+			injector.BindMulti(new(setListener)).To(setListenerA{})
+			injector.BindMulti(new(setListener)).To(setListenerB{})
+			injector.BindMulti(new(setListener)).To(setListenerA{})
+			injector.BindMulti(new(setListener)).To(setListenerB{}).PermitDuplicates()
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "b"}, setListenerNames(t, injector))
+	})
+
+	t.Run("keys and ordering are not compared", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.BindSet(new(setListener)).ToInstance(setListenerNamed{name: "a"}).WithKey("a")
+			injector.BindSet(new(setListener)).To(setListenerB{}).WithPriority(2)
+			injector.BindSet(new(setListener)).ToInstance(setListenerNamed{name: "a"}).WithPriority(1)
+			injector.BindSet(new(setListener)).To(setListenerB{}).Before("a")
+			injector.BindSet(new(setListener)).ToInstance(setListenerNamed{name: "c"})
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "a", "c"}, setListenerNames(t, injector), "the first registrations are kept")
+	})
+}
+
+type setListenerNamed struct {
+	name string
+}
+
+func (s setListenerNamed) Name() string { return s.name }
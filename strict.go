@@ -0,0 +1,71 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JustInTimeFilter allows the just in time creation of an unbound concrete type in strict mode
+type JustInTimeFilter func(t reflect.Type) bool
+
+// RequireExplicitBindings enables strict mode for the injector and its future children, like WithStrictMode.
+// Only explicitly bound types are resolved, e.g. via Bind(new(T)) without To, or types allowed via AllowJustInTime.
+func (injector *Injector) RequireExplicitBindings() {
+	injector.options.strict = true
+}
+
+// AllowJustInTime allows the just in time creation of the unbound types matched by one of the filters in strict mode
+func (injector *Injector) AllowJustInTime(filters ...JustInTimeFilter) {
+	injector.options.strictAllow = append(slices.Clip(injector.options.strictAllow), filters...)
+}
+
+// AllowPackages matches the types of the given packages, a path ending with /... matches all subpackages as well
+func AllowPackages(paths ...string) JustInTimeFilter {
+	return func(t reflect.Type) bool {
+		for _, path := range paths {
+			if prefix, ok := strings.CutSuffix(path, "/..."); ok {
+				if t.PkgPath() == prefix || strings.HasPrefix(t.PkgPath(), prefix+"/") {
+					return true
+				}
+			} else if t.PkgPath() == path {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllowTypes matches the given types, e.g. AllowTypes(new(MyStruct))
+func AllowTypes(types ...interface{}) JustInTimeFilter {
+	allowed := make(map[reflect.Type]bool, len(types))
+	for _, typ := range types {
+		allowed[typeOf(typ)] = true
+	}
+
+	return func(t reflect.Type) bool {
+		return allowed[t]
+	}
+}
+
+// checkStrict fails if the injector is in strict mode and the type would be created just in time without binding.
+// Anonymous types, such as the annotated structs used for Inject arguments, can not be bound and are always allowed.
+func (injector *Injector) checkStrict(t reflect.Type, annotation string, binding *Binding, optional bool) error {
+	if !injector.options.strict || binding != nil || optional || annotation != "" || t.Name() == "" {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface, reflect.Func, reflect.Slice, reflect.Map:
+		return nil
+	}
+
+	for _, allow := range injector.options.strictAllow {
+		if allow(t) {
+			return nil
+		}
+	}
+
+	return &UnboundError{Type: t, message: fmt.Sprintf("strict mode: no explicit binding for %s, bind it or allow it with AllowJustInTime", t)}
+}
@@ -0,0 +1,73 @@
+package dingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	strictRoot struct {
+		Dep   *strictDep   `inject:""`
+		Other *strictOther `inject:""`
+	}
+
+	strictDep struct{}
+
+	strictOther struct{}
+)
+
+func TestStrictMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("require explicit bindings in a module", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.RequireExplicitBindings()
+			injector.Bind(new(strictRoot))
+			injector.Bind(new(strictDep))
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(strictRoot))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.strictOther")
+
+		_, err = injector.GetInstance(new(strictDep))
+		assert.NoError(t, err, "untargeted bindings are explicit")
+	})
+
+	t.Run("allow types", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithStrictMode(AllowTypes(new(strictRoot), strictDep{})))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(strictRoot))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.strictOther")
+
+		injector.AllowJustInTime(AllowTypes(new(strictOther)))
+		_, err = injector.GetInstance(new(strictRoot))
+		assert.NoError(t, err)
+	})
+
+	t.Run("allow packages", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjectorWithOptions(WithStrictMode(AllowPackages("flamingo.me/dingo/other", "flamingo.me")))
+		require.NoError(t, err)
+		_, err = injector.GetInstance(new(strictRoot))
+		assert.ErrorContains(t, err, "strict mode: no explicit binding for dingo.strictRoot")
+
+		child, err := injector.Child()
+		require.NoError(t, err)
+		child.AllowJustInTime(AllowPackages("flamingo.me/..."))
+
+		_, err = child.GetInstance(new(strictRoot))
+		assert.NoError(t, err)
+
+		_, err = injector.GetInstance(new(strictRoot))
+		assert.Error(t, err, "the parent is not affected by the child's allowlist")
+	})
+}
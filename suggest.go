@@ -0,0 +1,150 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"flamingo.me/dingo/internal/levenshtein"
+)
+
+// maxSuggestions limits the annotations suggested for a typo
+const maxSuggestions = 3
+
+// boundAnnotations returns the sorted annotations a type is bound with in the injector and its parents,
+// including the keys of map bindings as map:key
+func (injector *Injector) boundAnnotations(t reflect.Type) []string {
+	var annotations []string
+	for i := injector; i != nil; i = i.parent {
+		for _, binding := range i.bindings[t] {
+			annotations = append(annotations, binding.annotatedWith)
+		}
+		for key := range i.mapbindings[t] {
+			if key, ok := key.(string); ok {
+				annotations = append(annotations, "map:"+key)
+			}
+		}
+	}
+
+	slices.Sort(annotations)
+	return slices.Compact(annotations)
+}
+
+// suggestAnnotations returns the bound annotations closest to a requested annotation by edit distance
+func suggestAnnotations(annotation string, annotations []string) []string {
+	type candidate struct {
+		annotation string
+		distance   int
+	}
+
+	limit := max(1, len(annotation)/3)
+	var candidates []candidate
+	for _, a := range annotations {
+		if a == "" || a == annotation {
+			continue
+		}
+		if d := levenshtein.Distance(annotation, a); d <= limit {
+			candidates = append(candidates, candidate{annotation: a, distance: d})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.distance - b.distance
+	})
+
+	suggestions := make([]string, 0, min(len(candidates), maxSuggestions))
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, c.annotation)
+	}
+	return suggestions
+}
+
+// implementations returns the sorted bound types of the injector and its parents which implement an interface
+func (injector *Injector) implementations(iface reflect.Type) []reflect.Type {
+	// every type implements an empty interface
+	if iface.NumMethod() == 0 {
+		return nil
+	}
+
+	var types []reflect.Type
+	for i := injector; i != nil; i = i.parent {
+		for t := range i.bindings {
+			if t.Kind() == reflect.Interface || slices.Contains(types, t) {
+				continue
+			}
+			if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+				types = append(types, t)
+			}
+		}
+	}
+
+	slices.SortFunc(types, func(a, b reflect.Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return types
+}
+
+// isBound checks if the injector or one of its parents has a binding for the type
+func (injector *Injector) isBound(t reflect.Type) bool {
+	for i := injector; i != nil; i = i.parent {
+		if len(i.bindings[t]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// annotationNotFound creates an AnnotationNotFoundError with suggestions for typos
+func (injector *Injector) annotationNotFound(t reflect.Type, annotation string) *AnnotationNotFoundError {
+	annotations := injector.boundAnnotations(t)
+	return &AnnotationNotFoundError{
+		Type:        t,
+		Annotation:  annotation,
+		Suggestions: suggestAnnotations(annotation, annotations),
+		Annotations: slices.DeleteFunc(annotations, func(a string) bool { return a == "" }),
+	}
+}
+
+// unboundInterface creates an UnboundError for an interface, noting bindings the user might have meant instead
+func (injector *Injector) unboundInterface(t reflect.Type) *UnboundError {
+	return &UnboundError{
+		Type:            t,
+		Annotations:     slices.DeleteFunc(injector.boundAnnotations(t), func(a string) bool { return a == "" }),
+		Implementations: injector.implementations(t),
+		PointerBound:    injector.isBound(reflect.PointerTo(t)),
+		message:         fmt.Sprintf("can not instantiate interface %s.%s", t.PkgPath(), t.Name()),
+	}
+}
+
+// hints describes the alternatives to an unresolved request
+func hints(t reflect.Type, suggestions, annotations []string, implementations []reflect.Type, pointerBound bool) string {
+	var sb strings.Builder
+
+	if len(suggestions) > 0 {
+		_, _ = fmt.Fprintf(&sb, ", did you mean %s?", quoteAll(suggestions))
+	}
+	if len(annotations) > 0 {
+		_, _ = fmt.Fprintf(&sb, " (%s is bound with annotations %s)", t, quoteAll(annotations))
+	}
+	if pointerBound {
+		_, _ = fmt.Fprintf(&sb, " (*%s is bound, bind the interface with Bind(new(%s)) instead)", t, t)
+	}
+	if len(implementations) > 0 {
+		names := make([]string, len(implementations))
+		for i, impl := range implementations {
+			names[i] = impl.String()
+		}
+		_, _ = fmt.Fprintf(&sb, " (bound types implementing it: %s, bind the interface with To)", strings.Join(names, ", "))
+	}
+
+	return sb.String()
+}
+
+func quoteAll(s []string) string {
+	quoted := make([]string, len(s))
+	for i, v := range s {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,87 @@
+package dingo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	suggestIface interface {
+		Suggest() string
+	}
+
+	suggestImpl struct{}
+)
+
+func (*suggestImpl) Suggest() string { return "impl" }
+
+func TestSuggestAnnotations(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"config:myModule.accountId"}, suggestAnnotations("config:myModule.acountId", []string{"", "config:myModule.accountId", "config:other.value"}))
+	assert.Equal(t, []string{"ab", "abcd"}, suggestAnnotations("abc", []string{"ab", "abcd", "xyz"}))
+	assert.Empty(t, suggestAnnotations("abc", []string{"xyz", "abc"}))
+}
+
+func TestResolutionErrorSuggestions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("annotations of the injector, its parents and map bindings", func(t *testing.T) {
+		t.Parallel()
+
+		parent, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(string)).AnnotatedWith("config:myModule.accountId").ToInstance("42")
+		}))
+		require.NoError(t, err)
+
+		injector, err := parent.Child()
+		require.NoError(t, err)
+		injector.BindMap(new(string), "acountIds").ToInstance("43")
+
+		_, err = injector.GetAnnotatedInstance(new(string), "config:myModule.acountId")
+
+		var notFound *AnnotationNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, []string{"config:myModule.accountId"}, notFound.Suggestions)
+		assert.Equal(t, []string{"config:myModule.accountId", "map:acountIds"}, notFound.Annotations)
+		assert.Contains(t, err.Error(), `did you mean "config:myModule.accountId"?`)
+	})
+
+	t.Run("interface bound with an annotation", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(suggestIface)).AnnotatedWith("impl").To(new(suggestImpl))
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(suggestIface))
+
+		var unbound *UnboundError
+		require.ErrorAs(t, err, &unbound)
+		assert.Equal(t, []string{"impl"}, unbound.Annotations)
+		assert.Contains(t, err.Error(), `bound with annotations "impl"`)
+	})
+
+	t.Run("pointer to interface and implementing type", func(t *testing.T) {
+		t.Parallel()
+
+		injector, err := NewInjector(ModuleFunc(func(injector *Injector) {
+			injector.Bind(new(*suggestIface)).ToInstance(new(suggestIface))
+			injector.Bind(new(suggestImpl))
+		}))
+		require.NoError(t, err)
+
+		_, err = injector.GetInstance(new(suggestIface))
+
+		var unbound *UnboundError
+		require.ErrorAs(t, err, &unbound)
+		assert.True(t, unbound.PointerBound)
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(suggestImpl{})}, unbound.Implementations)
+		assert.Contains(t, err.Error(), "*dingo.suggestIface is bound")
+		assert.Contains(t, err.Error(), "bound types implementing it: dingo.suggestImpl")
+	})
+}